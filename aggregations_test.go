@@ -105,7 +105,7 @@ func TestAggregations(t *testing.T) {
 			// eq.Aggregate(eq.TermsAgg("a1", "FIELD1").Size(0).Aggs(eq.Sum("a2", "FIELD2.SUBFIELD")))
 			Aggregate(
 				TermsAgg("categories", "categories").
-					Order(map[string]string{"priceSum": "desc"}).
+					Order(TermsOrderBy("priceSum", OrderDesc)).
 					Size(5).Aggs(Sum("priceSum", "price"))),
 			map[string]interface{}{
 				"aggs": map[string]interface{}{
@@ -166,3 +166,83 @@ func TestAggregations(t *testing.T) {
 		},
 	})
 }
+
+func TestTermsAggregation(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"terms agg with all options",
+			TermsAgg("tags", "tags").
+				Size(10).
+				ShardSize(50).
+				MinDocCount(2).
+				ShardMinDocCount(1).
+				Missing("N/A").
+				CollectMode(CollectModeBreadthFirst).
+				ExecutionHint(ExecutionHintMap).
+				IncludeRegex(".*sport.*").
+				ExcludeValues("water_sports"),
+			map[string]interface{}{
+				"terms": map[string]interface{}{
+					"field":               "tags",
+					"size":                10,
+					"shard_size":          50,
+					"min_doc_count":       2,
+					"shard_min_doc_count": 1,
+					"missing":             "N/A",
+					"collect_mode":        "breadth_first",
+					"execution_hint":      "map",
+					"include":             ".*sport.*",
+					"exclude":             []string{"water_sports"},
+				},
+			},
+		},
+		{
+			"terms agg with multi-key order",
+			TermsAgg("tags", "tags").
+				Order(
+					TermsOrderBy("avg_price", OrderDesc),
+					TermsOrderByCount(OrderDesc),
+					TermsOrderByKey(OrderAsc),
+				),
+			map[string]interface{}{
+				"terms": map[string]interface{}{
+					"field": "tags",
+					"order": []map[string]interface{}{
+						{"avg_price": "desc"},
+						{"_count": "desc"},
+						{"_key": "asc"},
+					},
+				},
+			},
+		},
+		{
+			"terms agg with partitions and exclude regex",
+			TermsAgg("accounts", "account_id").
+				IncludePartition(2, 20).
+				Exclude("test_.*"),
+			map[string]interface{}{
+				"terms": map[string]interface{}{
+					"field": "account_id",
+					"include": map[string]interface{}{
+						"partition":      2,
+						"num_partitions": 20,
+					},
+					"exclude": "test_.*",
+				},
+			},
+		},
+		{
+			"terms agg with script",
+			TermsAgg("genres", "").
+				Script(Script("").Source("doc['genre'].value").Lang("painless")),
+			map[string]interface{}{
+				"terms": map[string]interface{}{
+					"script": map[string]interface{}{
+						"source": "doc['genre'].value",
+						"lang":   "painless",
+					},
+				},
+			},
+		},
+	})
+}
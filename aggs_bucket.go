@@ -9,14 +9,79 @@ package osquery
 // TermsAggregation represents an aggregation of type "terms", as described in
 // https://opensearch.org/docs/latest/aggregations/bucket/terms/
 type TermsAggregation struct {
-	name        string
-	field       string
-	size        *uint64
-	shardSize   *float64
-	showTermDoc *bool
-	aggs        []Aggregation
-	order       map[string]string
-	include     []string
+	name             string
+	field            string
+	script           *ScriptField
+	size             *uint64
+	shardSize        *uint64
+	showTermDoc      *bool
+	minDocCount      *uint64
+	shardMinDocCount *uint64
+	missing          interface{}
+	collectMode      TermsCollectMode
+	executionHint    TermsExecutionHint
+	aggs             []Aggregation
+	order            []TermsOrder
+	include          interface{}
+	exclude          interface{}
+}
+
+// TermsCollectMode is an enumeration type for the "collect_mode" option of
+// terms aggregations.
+type TermsCollectMode string
+
+const (
+	// CollectModeDepthFirst builds the whole tree of sub-aggregations before
+	// pruning it. This is the default for most fields.
+	CollectModeDepthFirst TermsCollectMode = "depth_first"
+
+	// CollectModeBreadthFirst prunes the top-level buckets before computing
+	// sub-aggregations, which is cheaper when only a few of many buckets are
+	// returned.
+	CollectModeBreadthFirst TermsCollectMode = "breadth_first"
+)
+
+// TermsExecutionHint is an enumeration type for the "execution_hint" option
+// of terms aggregations.
+type TermsExecutionHint string
+
+const (
+	// ExecutionHintMap aggregates using the field values directly.
+	ExecutionHintMap TermsExecutionHint = "map"
+
+	// ExecutionHintGlobalOrdinals aggregates using global ordinals.
+	ExecutionHintGlobalOrdinals TermsExecutionHint = "global_ordinals"
+)
+
+// TermsOrder is a single sort key of a terms aggregation, as used by
+// TermsAggregation.Order.
+type TermsOrder struct {
+	key   string
+	order Order
+}
+
+// TermsOrderBy sorts buckets by the provided key, which may be the name of a
+// single-value metric sub-aggregation, a path to a value of a multi-value
+// metric sub-aggregation (e.g. "stats.avg"), "_count" or "_key".
+func TermsOrderBy(key string, order Order) TermsOrder {
+	return TermsOrder{key: key, order: order}
+}
+
+// TermsOrderByCount sorts buckets by their document count.
+func TermsOrderByCount(order Order) TermsOrder {
+	return TermsOrderBy("_count", order)
+}
+
+// TermsOrderByKey sorts buckets by their term.
+func TermsOrderByKey(order Order) TermsOrder {
+	return TermsOrderBy("_key", order)
+}
+
+// Map returns a map representation of the sort key.
+func (o TermsOrder) Map() map[string]interface{} {
+	return map[string]interface{}{
+		o.key: o.order,
+	}
 }
 
 // TermsAgg creates a new aggregation of type "terms". The method name includes
@@ -33,6 +98,13 @@ func (agg *TermsAggregation) Name() string {
 	return agg.name
 }
 
+// Script sets a script generating the terms to aggregate on, in which case
+// the field may be empty.
+func (agg *TermsAggregation) Script(script *ScriptField) *TermsAggregation {
+	agg.script = script
+	return agg
+}
+
 // Size sets the number of term buckets to return.
 func (agg *TermsAggregation) Size(size uint64) *TermsAggregation {
 	agg.size = &size
@@ -40,7 +112,7 @@ func (agg *TermsAggregation) Size(size uint64) *TermsAggregation {
 }
 
 // ShardSize sets how many terms to request from each shard.
-func (agg *TermsAggregation) ShardSize(size float64) *TermsAggregation {
+func (agg *TermsAggregation) ShardSize(size uint64) *TermsAggregation {
 	agg.shardSize = &size
 	return agg
 }
@@ -53,31 +125,132 @@ func (agg *TermsAggregation) ShowTermDocCountError(b bool) *TermsAggregation {
 	return agg
 }
 
+// MinDocCount sets the minimum number of documents a term must match to be
+// returned.
+func (agg *TermsAggregation) MinDocCount(min uint64) *TermsAggregation {
+	agg.minDocCount = &min
+	return agg
+}
+
+// ShardMinDocCount sets the minimum number of documents a term must match on
+// a shard to be returned by that shard.
+func (agg *TermsAggregation) ShardMinDocCount(min uint64) *TermsAggregation {
+	agg.shardMinDocCount = &min
+	return agg
+}
+
+// Missing sets the term used for documents missing the field.
+func (agg *TermsAggregation) Missing(missing interface{}) *TermsAggregation {
+	agg.missing = missing
+	return agg
+}
+
+// CollectMode sets how sub-aggregations are computed.
+func (agg *TermsAggregation) CollectMode(mode TermsCollectMode) *TermsAggregation {
+	agg.collectMode = mode
+	return agg
+}
+
+// ExecutionHint sets the mechanism used to execute the aggregation.
+func (agg *TermsAggregation) ExecutionHint(hint TermsExecutionHint) *TermsAggregation {
+	agg.executionHint = hint
+	return agg
+}
+
 // Aggs sets sub-aggregations for the aggregation.
 func (agg *TermsAggregation) Aggs(aggs ...Aggregation) *TermsAggregation {
 	agg.aggs = aggs
 	return agg
 }
 
-// Order sets the sort for terms agg
-func (agg *TermsAggregation) Order(order map[string]string) *TermsAggregation {
+func (agg *TermsAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
+// Order sets the sort keys of the buckets. Multiple keys are applied in the
+// provided order, the next key being used to break ties of the previous one.
+func (agg *TermsAggregation) Order(order ...TermsOrder) *TermsAggregation {
 	agg.order = order
 	return agg
 }
 
-// Include filter the values for  buckets
+// Include filter the values for buckets. A single value is interpreted as a
+// regular expression, multiple values as a list of exact terms. Use
+// IncludeRegex or IncludeValues to be explicit.
 func (agg *TermsAggregation) Include(include ...string) *TermsAggregation {
-	agg.include = include
+	agg.include = termsFilter(include)
+	return agg
+}
+
+// IncludeRegex only aggregates terms matching the provided regular
+// expression.
+func (agg *TermsAggregation) IncludeRegex(regex string) *TermsAggregation {
+	agg.include = regex
+	return agg
+}
+
+// IncludeValues only aggregates the provided terms.
+func (agg *TermsAggregation) IncludeValues(values ...string) *TermsAggregation {
+	agg.include = values
+	return agg
+}
+
+// IncludePartition splits terms into numPartitions groups and only aggregates
+// those of the provided partition (starting at 0), allowing to page through
+// high-cardinality fields with several requests.
+func (agg *TermsAggregation) IncludePartition(partition, numPartitions uint64) *TermsAggregation {
+	agg.include = map[string]interface{}{
+		"partition":      partition,
+		"num_partitions": numPartitions,
+	}
+	return agg
+}
+
+// Exclude filters out values from the buckets. A single value is interpreted
+// as a regular expression, multiple values as a list of exact terms. Use
+// ExcludeRegex or ExcludeValues to be explicit.
+func (agg *TermsAggregation) Exclude(exclude ...string) *TermsAggregation {
+	agg.exclude = termsFilter(exclude)
 	return agg
 }
 
+// ExcludeRegex does not aggregate terms matching the provided regular
+// expression.
+func (agg *TermsAggregation) ExcludeRegex(regex string) *TermsAggregation {
+	agg.exclude = regex
+	return agg
+}
+
+// ExcludeValues does not aggregate the provided terms.
+func (agg *TermsAggregation) ExcludeValues(values ...string) *TermsAggregation {
+	agg.exclude = values
+	return agg
+}
+
+// termsFilter returns the "include" or "exclude" value for the provided
+// values: a regular expression if there is a single one, a list otherwise.
+func termsFilter(values []string) interface{} {
+	switch len(values) {
+	case 0:
+		return nil
+	case 1:
+		return values[0]
+	default:
+		return values
+	}
+}
+
 // Map returns a map representation of the aggregation, thus implementing the
 // Mappable interface.
 func (agg *TermsAggregation) Map() map[string]interface{} {
-	innerMap := map[string]interface{}{
-		"field": agg.field,
-	}
+	innerMap := make(map[string]interface{})
 
+	if agg.field != "" {
+		innerMap["field"] = agg.field
+	}
+	if agg.script != nil {
+		innerMap["script"] = agg.script.Map()["script"]
+	}
 	if agg.size != nil {
 		innerMap["size"] = *agg.size
 	}
@@ -87,16 +260,35 @@ func (agg *TermsAggregation) Map() map[string]interface{} {
 	if agg.showTermDoc != nil {
 		innerMap["show_term_doc_count_error"] = *agg.showTermDoc
 	}
-	if agg.order != nil {
-		innerMap["order"] = agg.order
+	if agg.minDocCount != nil {
+		innerMap["min_doc_count"] = *agg.minDocCount
 	}
-
-	if agg.include != nil {
-		if len(agg.include) <= 1 {
-			innerMap["include"] = agg.include[0]
-		} else {
-			innerMap["include"] = agg.include
+	if agg.shardMinDocCount != nil {
+		innerMap["shard_min_doc_count"] = *agg.shardMinDocCount
+	}
+	if agg.missing != nil {
+		innerMap["missing"] = agg.missing
+	}
+	if agg.collectMode != "" {
+		innerMap["collect_mode"] = agg.collectMode
+	}
+	if agg.executionHint != "" {
+		innerMap["execution_hint"] = agg.executionHint
+	}
+	if len(agg.order) == 1 {
+		innerMap["order"] = agg.order[0].Map()
+	} else if len(agg.order) > 1 {
+		order := make([]map[string]interface{}, 0, len(agg.order))
+		for _, o := range agg.order {
+			order = append(order, o.Map())
 		}
+		innerMap["order"] = order
+	}
+	if agg.include != nil {
+		innerMap["include"] = agg.include
+	}
+	if agg.exclude != nil {
+		innerMap["exclude"] = agg.exclude
 	}
 
 	outerMap := map[string]interface{}{
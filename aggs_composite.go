@@ -0,0 +1,317 @@
+package osquery
+
+// CompositeAggregation represents an aggregation of type "composite", as
+// described in
+// https://opensearch.org/docs/latest/aggregations/bucket/composite/
+// It creates a bucket for every combination of the values of its sources,
+// and pages through them via "after_key". See IterateComposite for a helper
+// fetching all pages.
+type CompositeAggregation struct {
+	name    string
+	sources []CompositeSource
+	size    *uint64
+	after   map[string]interface{}
+	aggs    []Aggregation
+}
+
+// CompositeSource is a value source of a composite aggregation, such as
+// CompositeTerms, CompositeHistogram or CompositeDateHistogram.
+type CompositeSource interface {
+	Mappable
+	Name() string
+}
+
+// CompositeAgg creates a new aggregation of type "composite" with the
+// provided sources. The order of the sources determines the order of the
+// buckets.
+func CompositeAgg(name string, sources ...CompositeSource) *CompositeAggregation {
+	return &CompositeAggregation{
+		name:    name,
+		sources: sources,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *CompositeAggregation) Name() string {
+	return agg.name
+}
+
+// Sources appends value sources to the aggregation.
+func (agg *CompositeAggregation) Sources(sources ...CompositeSource) *CompositeAggregation {
+	agg.sources = append(agg.sources, sources...)
+	return agg
+}
+
+// Size sets the number of buckets returned per page.
+func (agg *CompositeAggregation) Size(size uint64) *CompositeAggregation {
+	agg.size = &size
+	return agg
+}
+
+// After sets the key after which buckets are returned, usually the
+// "after_key" of the previous page.
+func (agg *CompositeAggregation) After(after map[string]interface{}) *CompositeAggregation {
+	agg.after = after
+	return agg
+}
+
+// Aggs sets sub-aggregations for the aggregation.
+func (agg *CompositeAggregation) Aggs(aggs ...Aggregation) *CompositeAggregation {
+	agg.aggs = aggs
+	return agg
+}
+
+func (agg *CompositeAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *CompositeAggregation) Map() map[string]interface{} {
+	sources := make([]map[string]interface{}, 0, len(agg.sources))
+	for _, src := range agg.sources {
+		sources = append(sources, map[string]interface{}{
+			src.Name(): src.Map(),
+		})
+	}
+
+	innerMap := map[string]interface{}{
+		"sources": sources,
+	}
+	if agg.size != nil {
+		innerMap["size"] = *agg.size
+	}
+	if len(agg.after) > 0 {
+		innerMap["after"] = agg.after
+	}
+
+	outerMap := map[string]interface{}{
+		"composite": innerMap,
+	}
+	if len(agg.aggs) > 0 {
+		subAggs := make(map[string]map[string]interface{})
+		for _, sub := range agg.aggs {
+			subAggs[sub.Name()] = sub.Map()
+		}
+		outerMap["aggs"] = subAggs
+	}
+
+	return outerMap
+}
+
+// compositeSourceMap builds the map of a composite value source, adding the
+// options shared by all source types.
+func compositeSourceMap(
+	srcType string,
+	innerMap map[string]interface{},
+	order Order,
+	missingBucket *bool,
+) map[string]interface{} {
+	if order != "" {
+		innerMap["order"] = order
+	}
+	if missingBucket != nil {
+		innerMap["missing_bucket"] = *missingBucket
+	}
+	return map[string]interface{}{
+		srcType: innerMap,
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// CompositeTermsSource represents a "terms" value source of a composite
+// aggregation.
+type CompositeTermsSource struct {
+	name          string
+	field         string
+	script        *ScriptField
+	order         Order
+	missingBucket *bool
+}
+
+// CompositeTerms creates a new "terms" value source.
+func CompositeTerms(name, field string) *CompositeTermsSource {
+	return &CompositeTermsSource{
+		name:  name,
+		field: field,
+	}
+}
+
+// Name returns the name of the source.
+func (src *CompositeTermsSource) Name() string {
+	return src.name
+}
+
+// Script sets a script generating the source's values, in which case the
+// field may be empty.
+func (src *CompositeTermsSource) Script(script *ScriptField) *CompositeTermsSource {
+	src.script = script
+	return src
+}
+
+// Order sets the order of the source's values.
+func (src *CompositeTermsSource) Order(order Order) *CompositeTermsSource {
+	src.order = order
+	return src
+}
+
+// MissingBucket sets whether documents without a value for the source are
+// included in a bucket with a null key.
+func (src *CompositeTermsSource) MissingBucket(b bool) *CompositeTermsSource {
+	src.missingBucket = &b
+	return src
+}
+
+// Map returns a map representation of the source, thus implementing the
+// Mappable interface.
+func (src *CompositeTermsSource) Map() map[string]interface{} {
+	innerMap := make(map[string]interface{})
+	if src.field != "" {
+		innerMap["field"] = src.field
+	}
+	if src.script != nil {
+		innerMap["script"] = src.script.Map()["script"]
+	}
+
+	return compositeSourceMap("terms", innerMap, src.order, src.missingBucket)
+}
+
+//----------------------------------------------------------------------------//
+
+// CompositeHistogramSource represents a "histogram" value source of a
+// composite aggregation.
+type CompositeHistogramSource struct {
+	name          string
+	field         string
+	interval      float64
+	order         Order
+	missingBucket *bool
+}
+
+// CompositeHistogram creates a new "histogram" value source.
+func CompositeHistogram(name, field string, interval float64) *CompositeHistogramSource {
+	return &CompositeHistogramSource{
+		name:     name,
+		field:    field,
+		interval: interval,
+	}
+}
+
+// Name returns the name of the source.
+func (src *CompositeHistogramSource) Name() string {
+	return src.name
+}
+
+// Order sets the order of the source's values.
+func (src *CompositeHistogramSource) Order(order Order) *CompositeHistogramSource {
+	src.order = order
+	return src
+}
+
+// MissingBucket sets whether documents without a value for the source are
+// included in a bucket with a null key.
+func (src *CompositeHistogramSource) MissingBucket(b bool) *CompositeHistogramSource {
+	src.missingBucket = &b
+	return src
+}
+
+// Map returns a map representation of the source, thus implementing the
+// Mappable interface.
+func (src *CompositeHistogramSource) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"field":    src.field,
+		"interval": src.interval,
+	}
+
+	return compositeSourceMap("histogram", innerMap, src.order, src.missingBucket)
+}
+
+//----------------------------------------------------------------------------//
+
+// CompositeDateHistogramSource represents a "date_histogram" value source of
+// a composite aggregation.
+type CompositeDateHistogramSource struct {
+	name             string
+	field            string
+	calendarInterval string
+	fixedInterval    string
+	timeZone         string
+	format           string
+	order            Order
+	missingBucket    *bool
+}
+
+// CompositeDateHistogram creates a new "date_histogram" value source. One of
+// CalendarInterval or FixedInterval must be set.
+func CompositeDateHistogram(name, field string) *CompositeDateHistogramSource {
+	return &CompositeDateHistogramSource{
+		name:  name,
+		field: field,
+	}
+}
+
+// Name returns the name of the source.
+func (src *CompositeDateHistogramSource) Name() string {
+	return src.name
+}
+
+// CalendarInterval sets a calendar-aware interval, such as "day" or "1M".
+func (src *CompositeDateHistogramSource) CalendarInterval(interval string) *CompositeDateHistogramSource {
+	src.calendarInterval = interval
+	return src
+}
+
+// FixedInterval sets a fixed interval, such as "30m" or "12h".
+func (src *CompositeDateHistogramSource) FixedInterval(interval string) *CompositeDateHistogramSource {
+	src.fixedInterval = interval
+	return src
+}
+
+// TimeZone sets the time zone used for bucketing.
+func (src *CompositeDateHistogramSource) TimeZone(tz string) *CompositeDateHistogramSource {
+	src.timeZone = tz
+	return src
+}
+
+// Format sets the date format of the source's keys. Without a format, keys
+// are milliseconds since the epoch.
+func (src *CompositeDateHistogramSource) Format(format string) *CompositeDateHistogramSource {
+	src.format = format
+	return src
+}
+
+// Order sets the order of the source's values.
+func (src *CompositeDateHistogramSource) Order(order Order) *CompositeDateHistogramSource {
+	src.order = order
+	return src
+}
+
+// MissingBucket sets whether documents without a value for the source are
+// included in a bucket with a null key.
+func (src *CompositeDateHistogramSource) MissingBucket(b bool) *CompositeDateHistogramSource {
+	src.missingBucket = &b
+	return src
+}
+
+// Map returns a map representation of the source, thus implementing the
+// Mappable interface.
+func (src *CompositeDateHistogramSource) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"field": src.field,
+	}
+	if src.calendarInterval != "" {
+		innerMap["calendar_interval"] = src.calendarInterval
+	}
+	if src.fixedInterval != "" {
+		innerMap["fixed_interval"] = src.fixedInterval
+	}
+	if src.timeZone != "" {
+		innerMap["time_zone"] = src.timeZone
+	}
+	if src.format != "" {
+		innerMap["format"] = src.format
+	}
+
+	return compositeSourceMap("date_histogram", innerMap, src.order, src.missingBucket)
+}
@@ -0,0 +1,95 @@
+package osquery
+
+import "testing"
+
+func TestCompositeAggs(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"composite agg: terms sources",
+			CompositeAgg("combos",
+				CompositeTerms("city", "city"),
+				CompositeTerms("category", "category").Order(OrderDesc).MissingBucket(true),
+			).Size(100),
+			map[string]interface{}{
+				"composite": map[string]interface{}{
+					"size": 100,
+					"sources": []map[string]interface{}{
+						{"city": map[string]interface{}{
+							"terms": map[string]interface{}{"field": "city"},
+						}},
+						{"category": map[string]interface{}{
+							"terms": map[string]interface{}{
+								"field":          "category",
+								"order":          "desc",
+								"missing_bucket": true,
+							},
+						}},
+					},
+				},
+			},
+		},
+		{
+			"composite agg: histogram sources, after key and sub-aggs",
+			CompositeAgg("combos").
+				Sources(
+					CompositeHistogram("price", "price", 50),
+					CompositeDateHistogram("day", "published_at").
+						CalendarInterval("day").
+						TimeZone("UTC").
+						Format("yyyy-MM-dd"),
+				).
+				After(map[string]interface{}{"price": 50, "day": "2024-03-01"}).
+				Aggs(Avg("avg_rating", "rating")),
+			map[string]interface{}{
+				"composite": map[string]interface{}{
+					"sources": []map[string]interface{}{
+						{"price": map[string]interface{}{
+							"histogram": map[string]interface{}{
+								"field":    "price",
+								"interval": 50.0,
+							},
+						}},
+						{"day": map[string]interface{}{
+							"date_histogram": map[string]interface{}{
+								"field":             "published_at",
+								"calendar_interval": "day",
+								"time_zone":         "UTC",
+								"format":            "yyyy-MM-dd",
+							},
+						}},
+					},
+					"after": map[string]interface{}{
+						"price": 50,
+						"day":   "2024-03-01",
+					},
+				},
+				"aggs": map[string]interface{}{
+					"avg_rating": map[string]interface{}{
+						"avg": map[string]interface{}{
+							"field": "rating",
+						},
+					},
+				},
+			},
+		},
+		{
+			"composite agg: scripted terms source",
+			CompositeAgg("combos",
+				CompositeTerms("genre", "").Script(Script("").Source("doc['genre'].value")),
+			),
+			map[string]interface{}{
+				"composite": map[string]interface{}{
+					"sources": []map[string]interface{}{
+						{"genre": map[string]interface{}{
+							"terms": map[string]interface{}{
+								"script": map[string]interface{}{
+									"source": "doc['genre'].value",
+								},
+							},
+						}},
+					},
+				},
+			},
+		},
+	})
+}
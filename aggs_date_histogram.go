@@ -0,0 +1,156 @@
+package osquery
+
+// DateHistogramAggregation represents an aggregation of type "date_histogram",
+// as described in:
+// https://opensearch.org/docs/latest/aggregations/bucket/date-histogram/
+type DateHistogramAggregation struct {
+	name             string
+	field            string
+	calendarInterval string
+	fixedInterval    string
+	timeZone         string
+	format           string
+	offset           string
+	minDocCount      *int
+	extendedBounds   *histogramBounds
+	hardBounds       *histogramBounds
+	aggs             []Aggregation
+}
+
+// histogramBounds represents the "extended_bounds" and "hard_bounds" options
+// of histogram aggregations.
+type histogramBounds struct {
+	min interface{}
+	max interface{}
+}
+
+func (b *histogramBounds) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"min": b.min,
+		"max": b.max,
+	}
+}
+
+// DateHistogramAgg creates a new date histogram aggregation. One of
+// CalendarInterval or FixedInterval must be set.
+func DateHistogramAgg(name string, field string) *DateHistogramAggregation {
+	return &DateHistogramAggregation{
+		name:  name,
+		field: field,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *DateHistogramAggregation) Name() string {
+	return agg.name
+}
+
+// CalendarInterval sets a calendar-aware interval, such as "day", "1M" or
+// "quarter", whose duration may vary (e.g. months of different lengths).
+func (agg *DateHistogramAggregation) CalendarInterval(interval string) *DateHistogramAggregation {
+	agg.calendarInterval = interval
+	return agg
+}
+
+// FixedInterval sets a fixed interval, such as "30m" or "12h", whose duration
+// is always the same.
+func (agg *DateHistogramAggregation) FixedInterval(interval string) *DateHistogramAggregation {
+	agg.fixedInterval = interval
+	return agg
+}
+
+// TimeZone sets the time zone used for bucketing and formatting keys, either
+// as an offset (e.g. "-05:00") or an IANA ID (e.g. "Europe/Paris").
+func (agg *DateHistogramAggregation) TimeZone(tz string) *DateHistogramAggregation {
+	agg.timeZone = tz
+	return agg
+}
+
+// Format sets the date format of the buckets' "key_as_string".
+func (agg *DateHistogramAggregation) Format(format string) *DateHistogramAggregation {
+	agg.format = format
+	return agg
+}
+
+// Offset sets a duration, such as "+6h", by which bucket boundaries are
+// shifted.
+func (agg *DateHistogramAggregation) Offset(offset string) *DateHistogramAggregation {
+	agg.offset = offset
+	return agg
+}
+
+// MinDocCount sets the optional minimum document count for buckets.
+func (agg *DateHistogramAggregation) MinDocCount(min int) *DateHistogramAggregation {
+	agg.minDocCount = &min
+	return agg
+}
+
+// ExtendedBounds forces empty buckets to be returned from min to max, which
+// may be dates or date math expressions. It requires MinDocCount(0).
+func (agg *DateHistogramAggregation) ExtendedBounds(min, max interface{}) *DateHistogramAggregation {
+	agg.extendedBounds = &histogramBounds{min, max}
+	return agg
+}
+
+// HardBounds limits the buckets to the range from min to max, which may be
+// dates or date math expressions.
+func (agg *DateHistogramAggregation) HardBounds(min, max interface{}) *DateHistogramAggregation {
+	agg.hardBounds = &histogramBounds{min, max}
+	return agg
+}
+
+// Aggs sets sub-aggregations for the date histogram buckets.
+func (agg *DateHistogramAggregation) Aggs(aggs ...Aggregation) *DateHistogramAggregation {
+	agg.aggs = aggs
+	return agg
+}
+
+func (agg *DateHistogramAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
+// Map builds the OpenSearch aggregation map.
+func (agg *DateHistogramAggregation) Map() map[string]interface{} {
+	histogramMap := map[string]interface{}{
+		"field": agg.field,
+	}
+
+	if agg.calendarInterval != "" {
+		histogramMap["calendar_interval"] = agg.calendarInterval
+	}
+	if agg.fixedInterval != "" {
+		histogramMap["fixed_interval"] = agg.fixedInterval
+	}
+	if agg.timeZone != "" {
+		histogramMap["time_zone"] = agg.timeZone
+	}
+	if agg.format != "" {
+		histogramMap["format"] = agg.format
+	}
+	if agg.offset != "" {
+		histogramMap["offset"] = agg.offset
+	}
+	if agg.minDocCount != nil {
+		histogramMap["min_doc_count"] = *agg.minDocCount
+	}
+	if agg.extendedBounds != nil {
+		histogramMap["extended_bounds"] = agg.extendedBounds.Map()
+	}
+	if agg.hardBounds != nil {
+		histogramMap["hard_bounds"] = agg.hardBounds.Map()
+	}
+
+	outerMap := map[string]interface{}{
+		"date_histogram": histogramMap,
+	}
+
+	if len(agg.aggs) > 0 {
+		subAggs := make(map[string]map[string]interface{})
+		for _, sub := range agg.aggs {
+			subAggs[sub.Name()] = sub.Map()
+		}
+		outerMap["aggs"] = subAggs
+	}
+
+	return outerMap
+}
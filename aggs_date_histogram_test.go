@@ -0,0 +1,67 @@
+package osquery
+
+import "testing"
+
+func TestDateHistogramAggs(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"date histogram agg: calendar interval",
+			DateHistogramAgg("per_month", "published_at").
+				CalendarInterval("month"),
+			map[string]interface{}{
+				"date_histogram": map[string]interface{}{
+					"field":             "published_at",
+					"calendar_interval": "month",
+				},
+			},
+		},
+		{
+			"date histogram agg: fixed interval with all options",
+			DateHistogramAgg("per_12h", "published_at").
+				FixedInterval("12h").
+				TimeZone("Europe/Paris").
+				Format("yyyy-MM-dd HH:mm").
+				Offset("+6h").
+				MinDocCount(0).
+				ExtendedBounds("2024-01-01", "2024-12-31").
+				HardBounds("now-1y", "now"),
+			map[string]interface{}{
+				"date_histogram": map[string]interface{}{
+					"field":          "published_at",
+					"fixed_interval": "12h",
+					"time_zone":      "Europe/Paris",
+					"format":         "yyyy-MM-dd HH:mm",
+					"offset":         "+6h",
+					"min_doc_count":  0,
+					"extended_bounds": map[string]interface{}{
+						"min": "2024-01-01",
+						"max": "2024-12-31",
+					},
+					"hard_bounds": map[string]interface{}{
+						"min": "now-1y",
+						"max": "now",
+					},
+				},
+			},
+		},
+		{
+			"date histogram agg: with sub-aggs",
+			DateHistogramAgg("per_day", "published_at").
+				CalendarInterval("day").
+				Aggs(Sum("total_views", "views")),
+			map[string]interface{}{
+				"date_histogram": map[string]interface{}{
+					"field":             "published_at",
+					"calendar_interval": "day",
+				},
+				"aggs": map[string]interface{}{
+					"total_views": map[string]interface{}{
+						"sum": map[string]interface{}{
+							"field": "views",
+						},
+					},
+				},
+			},
+		},
+	})
+}
@@ -36,6 +36,10 @@ func (agg *FilterAggregation) Aggs(aggs ...Aggregation) *FilterAggregation {
 	return agg
 }
 
+func (agg *FilterAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
 func (agg *FilterAggregation) Map() map[string]interface{} {
 	outerMap := map[string]interface{}{
 		"filter": agg.filter.Map(),
@@ -51,3 +55,116 @@ func (agg *FilterAggregation) Map() map[string]interface{} {
 
 	return outerMap
 }
+
+//----------------------------------------------------------------------------//
+
+// FiltersAggregation represents an aggregation of type "filters", as
+// described in
+// https://opensearch.org/docs/latest/aggregations/bucket/filters/
+// It creates a bucket per filter, either named (see Filter) or anonymous
+// (see AnonymousFilters).
+type FiltersAggregation struct {
+	name           string
+	keys           []string
+	filters        []Mappable
+	anonymous      bool
+	otherBucket    *bool
+	otherBucketKey string
+	aggs           []Aggregation
+}
+
+// FiltersAgg creates a new aggregation of type "filters".
+func FiltersAgg(name string) *FiltersAggregation {
+	return &FiltersAggregation{
+		name: name,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *FiltersAggregation) Name() string {
+	return agg.name
+}
+
+// Filter adds a filter whose bucket is named with the provided key. It
+// replaces any filter set via AnonymousFilters.
+func (agg *FiltersAggregation) Filter(key string, filter Mappable) *FiltersAggregation {
+	if agg.anonymous {
+		agg.filters = nil
+		agg.anonymous = false
+	}
+	agg.keys = append(agg.keys, key)
+	agg.filters = append(agg.filters, filter)
+	return agg
+}
+
+// AnonymousFilters sets unnamed filters, whose buckets are returned as an
+// array in the order of the filters. It replaces any filter set via Filter.
+func (agg *FiltersAggregation) AnonymousFilters(filters ...Mappable) *FiltersAggregation {
+	agg.keys = nil
+	agg.filters = filters
+	agg.anonymous = true
+	return agg
+}
+
+// OtherBucket sets whether a bucket is added for documents matching none of
+// the filters.
+func (agg *FiltersAggregation) OtherBucket(b bool) *FiltersAggregation {
+	agg.otherBucket = &b
+	return agg
+}
+
+// OtherBucketKey sets the key of the bucket for documents matching none of
+// the filters, enabling it. The default key is "_other_".
+func (agg *FiltersAggregation) OtherBucketKey(key string) *FiltersAggregation {
+	agg.otherBucketKey = key
+	return agg
+}
+
+// Aggs sets sub-aggregations for the aggregation.
+func (agg *FiltersAggregation) Aggs(aggs ...Aggregation) *FiltersAggregation {
+	agg.aggs = aggs
+	return agg
+}
+
+func (agg *FiltersAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *FiltersAggregation) Map() map[string]interface{} {
+	innerMap := make(map[string]interface{})
+	if agg.anonymous {
+		filters := make([]map[string]interface{}, 0, len(agg.filters))
+		for _, filter := range agg.filters {
+			filters = append(filters, filter.Map())
+		}
+		innerMap["filters"] = filters
+	} else {
+		filters := make(map[string]interface{}, len(agg.filters))
+		for i, filter := range agg.filters {
+			filters[agg.keys[i]] = filter.Map()
+		}
+		innerMap["filters"] = filters
+	}
+	if agg.otherBucket != nil {
+		innerMap["other_bucket"] = *agg.otherBucket
+	}
+	if agg.otherBucketKey != "" {
+		innerMap["other_bucket_key"] = agg.otherBucketKey
+	}
+
+	outerMap := map[string]interface{}{
+		"filters": innerMap,
+	}
+
+	if len(agg.aggs) > 0 {
+		subAggs := make(map[string]map[string]interface{})
+		for _, sub := range agg.aggs {
+			subAggs[sub.Name()] = sub.Map()
+		}
+		outerMap["aggs"] = subAggs
+	}
+
+	return outerMap
+}
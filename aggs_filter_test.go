@@ -44,3 +44,95 @@ func TestFilterAggs(t *testing.T) {
 		},
 	})
 }
+
+func TestFiltersAggs(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"filters agg: named filters with other bucket",
+			FiltersAgg("messages").
+				Filter("errors", Match("body").Query("error")).
+				Filter("warnings", Match("body").Query("warning")).
+				OtherBucketKey("other_messages").
+				Aggs(Avg("avg_size", "size")),
+			map[string]interface{}{
+				"filters": map[string]interface{}{
+					"filters": map[string]interface{}{
+						"errors": map[string]interface{}{
+							"match": map[string]interface{}{
+								"body": map[string]interface{}{
+									"query": "error",
+								},
+							},
+						},
+						"warnings": map[string]interface{}{
+							"match": map[string]interface{}{
+								"body": map[string]interface{}{
+									"query": "warning",
+								},
+							},
+						},
+					},
+					"other_bucket_key": "other_messages",
+				},
+				"aggs": map[string]interface{}{
+					"avg_size": map[string]interface{}{
+						"avg": map[string]interface{}{
+							"field": "size",
+						},
+					},
+				},
+			},
+		},
+		{
+			"filters agg: anonymous filters",
+			FiltersAgg("messages").
+				AnonymousFilters(Term("level", "error"), Term("level", "warning")).
+				OtherBucket(true),
+			map[string]interface{}{
+				"filters": map[string]interface{}{
+					"filters": []map[string]interface{}{
+						{"term": map[string]interface{}{
+							"level": map[string]interface{}{"value": "error"},
+						}},
+						{"term": map[string]interface{}{
+							"level": map[string]interface{}{"value": "warning"},
+						}},
+					},
+					"other_bucket": true,
+				},
+			},
+		},
+		{
+			"filters agg: named filter replaces anonymous filters",
+			FiltersAgg("messages").
+				AnonymousFilters(Term("level", "error")).
+				Filter("warnings", Term("level", "warning")),
+			map[string]interface{}{
+				"filters": map[string]interface{}{
+					"filters": map[string]interface{}{
+						"warnings": map[string]interface{}{
+							"term": map[string]interface{}{
+								"level": map[string]interface{}{"value": "warning"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"filters agg: anonymous filters replace named filter",
+			FiltersAgg("messages").
+				Filter("warnings", Term("level", "warning")).
+				AnonymousFilters(Term("level", "error")),
+			map[string]interface{}{
+				"filters": map[string]interface{}{
+					"filters": []map[string]interface{}{
+						{"term": map[string]interface{}{
+							"level": map[string]interface{}{"value": "error"},
+						}},
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,197 @@
+package osquery
+
+// GeoGridAggregation represents an aggregation of type "geohash_grid" or
+// "geotile_grid", as described in
+// https://opensearch.org/docs/latest/aggregations/bucket/geohash-grid/ and
+// https://opensearch.org/docs/latest/aggregations/bucket/geotile-grid/
+// Both group points into the cells of a grid, whose key is a geohash or a
+// "zoom/x/y" tile respectively.
+type GeoGridAggregation struct {
+	name      string
+	apiName   string
+	field     string
+	precision *uint8
+	bounds    *[2]GeoPoint
+	size      *uint64
+	shardSize *uint64
+	aggs      []Aggregation
+}
+
+// GeoHashGridAgg creates a new aggregation of type "geohash_grid".
+func GeoHashGridAgg(name, field string) *GeoGridAggregation {
+	return &GeoGridAggregation{
+		name:    name,
+		apiName: "geohash_grid",
+		field:   field,
+	}
+}
+
+// GeoTileGridAgg creates a new aggregation of type "geotile_grid".
+func GeoTileGridAgg(name, field string) *GeoGridAggregation {
+	return &GeoGridAggregation{
+		name:    name,
+		apiName: "geotile_grid",
+		field:   field,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *GeoGridAggregation) Name() string {
+	return agg.name
+}
+
+// Precision sets the precision of the grid: the geohash length (1 to 12) for
+// "geohash_grid" aggregations, and the zoom level (0 to 29) for
+// "geotile_grid" aggregations.
+func (agg *GeoGridAggregation) Precision(p uint8) *GeoGridAggregation {
+	agg.precision = &p
+	return agg
+}
+
+// Bounds restricts the grid to the rectangle with the provided top left and
+// bottom right corners.
+func (agg *GeoGridAggregation) Bounds(topLeft, bottomRight GeoPoint) *GeoGridAggregation {
+	agg.bounds = &[2]GeoPoint{topLeft, bottomRight}
+	return agg
+}
+
+// Size sets the maximum number of cells to return.
+func (agg *GeoGridAggregation) Size(size uint64) *GeoGridAggregation {
+	agg.size = &size
+	return agg
+}
+
+// ShardSize sets how many cells to request from each shard.
+func (agg *GeoGridAggregation) ShardSize(size uint64) *GeoGridAggregation {
+	agg.shardSize = &size
+	return agg
+}
+
+// Aggs sets sub-aggregations for the aggregation.
+func (agg *GeoGridAggregation) Aggs(aggs ...Aggregation) *GeoGridAggregation {
+	agg.aggs = aggs
+	return agg
+}
+
+func (agg *GeoGridAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *GeoGridAggregation) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"field": agg.field,
+	}
+	if agg.precision != nil {
+		innerMap["precision"] = *agg.precision
+	}
+	if agg.bounds != nil {
+		innerMap["bounds"] = map[string]interface{}{
+			"top_left":     agg.bounds[0].Map(),
+			"bottom_right": agg.bounds[1].Map(),
+		}
+	}
+	if agg.size != nil {
+		innerMap["size"] = *agg.size
+	}
+	if agg.shardSize != nil {
+		innerMap["shard_size"] = *agg.shardSize
+	}
+
+	outerMap := map[string]interface{}{
+		agg.apiName: innerMap,
+	}
+
+	if len(agg.aggs) > 0 {
+		subAggs := make(map[string]map[string]interface{})
+		for _, sub := range agg.aggs {
+			subAggs[sub.Name()] = sub.Map()
+		}
+		outerMap["aggs"] = subAggs
+	}
+
+	return outerMap
+}
+
+//----------------------------------------------------------------------------//
+
+// GeoBoundsAggregation represents an aggregation of type "geo_bounds", as described
+// in https://opensearch.org/docs/latest/aggregations/metric/geobounds/
+// It computes the bounding box containing all points of a field.
+type GeoBoundsAggregation struct {
+	name          string
+	field         string
+	wrapLongitude *bool
+}
+
+// GeoBoundsAgg creates a new aggregation of type "geo_bounds", with the provided
+// name and on the provided field.
+func GeoBoundsAgg(name, field string) *GeoBoundsAggregation {
+	return &GeoBoundsAggregation{
+		name:  name,
+		field: field,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *GeoBoundsAggregation) Name() string {
+	return agg.name
+}
+
+// WrapLongitude sets whether the bounding box may overlap the international
+// date line. The default is true.
+func (agg *GeoBoundsAggregation) WrapLongitude(b bool) *GeoBoundsAggregation {
+	agg.wrapLongitude = &b
+	return agg
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *GeoBoundsAggregation) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"field": agg.field,
+	}
+	if agg.wrapLongitude != nil {
+		innerMap["wrap_longitude"] = *agg.wrapLongitude
+	}
+
+	return map[string]interface{}{
+		"geo_bounds": innerMap,
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// GeoCentroidAggregation represents an aggregation of type "geo_centroid", as
+// described in
+// https://opensearch.org/docs/latest/aggregations/metric/geocentroid/
+// It computes the weighted centroid of all points of a field.
+type GeoCentroidAggregation struct {
+	name  string
+	field string
+}
+
+// GeoCentroidAgg creates a new aggregation of type "geo_centroid", with the
+// provided name and on the provided field.
+func GeoCentroidAgg(name, field string) *GeoCentroidAggregation {
+	return &GeoCentroidAggregation{
+		name:  name,
+		field: field,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *GeoCentroidAggregation) Name() string {
+	return agg.name
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *GeoCentroidAggregation) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"geo_centroid": map[string]interface{}{
+			"field": agg.field,
+		},
+	}
+}
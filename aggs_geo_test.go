@@ -0,0 +1,59 @@
+package osquery
+
+import (
+	"testing"
+)
+
+func TestGeoAggs(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"geohash_grid agg",
+			GeoHashGridAgg("cells", "location").
+				Precision(5).
+				Size(100).
+				ShardSize(500).
+				Aggs(GeoCentroidAgg("center", "location")),
+			map[string]interface{}{
+				"geohash_grid": map[string]interface{}{
+					"field":      "location",
+					"precision":  5,
+					"size":       100,
+					"shard_size": 500,
+				},
+				"aggs": map[string]interface{}{
+					"center": map[string]interface{}{
+						"geo_centroid": map[string]interface{}{
+							"field": "location",
+						},
+					},
+				},
+			},
+		},
+		{
+			"geotile_grid agg with bounds",
+			GeoTileGridAgg("tiles", "location").
+				Precision(8).
+				Bounds(LatLon(53, 13), LatLon(52, 14)),
+			map[string]interface{}{
+				"geotile_grid": map[string]interface{}{
+					"field":     "location",
+					"precision": 8,
+					"bounds": map[string]interface{}{
+						"top_left":     map[string]interface{}{"lat": 53, "lon": 13},
+						"bottom_right": map[string]interface{}{"lat": 52, "lon": 14},
+					},
+				},
+			},
+		},
+		{
+			"geo_bounds agg",
+			GeoBoundsAgg("viewport", "location").WrapLongitude(false),
+			map[string]interface{}{
+				"geo_bounds": map[string]interface{}{
+					"field":          "location",
+					"wrap_longitude": false,
+				},
+			},
+		},
+	})
+}
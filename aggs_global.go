@@ -0,0 +1,104 @@
+package osquery
+
+// GlobalAggregation represents an aggregation of type "global", as described
+// in https://opensearch.org/docs/latest/aggregations/bucket/global/
+// Its single bucket holds all documents of the searched indices, regardless
+// of the search query. It must be a top-level aggregation.
+type GlobalAggregation struct {
+	name string
+	aggs []Aggregation
+}
+
+// GlobalAgg creates a new aggregation of type "global".
+func GlobalAgg(name string) *GlobalAggregation {
+	return &GlobalAggregation{
+		name: name,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *GlobalAggregation) Name() string {
+	return agg.name
+}
+
+// Aggs sets sub-aggregations for the aggregation.
+func (agg *GlobalAggregation) Aggs(aggs ...Aggregation) *GlobalAggregation {
+	agg.aggs = aggs
+	return agg
+}
+
+func (agg *GlobalAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *GlobalAggregation) Map() map[string]interface{} {
+	outerMap := map[string]interface{}{
+		"global": map[string]interface{}{},
+	}
+
+	if len(agg.aggs) > 0 {
+		subAggs := make(map[string]map[string]interface{})
+		for _, sub := range agg.aggs {
+			subAggs[sub.Name()] = sub.Map()
+		}
+		outerMap["aggs"] = subAggs
+	}
+
+	return outerMap
+}
+
+//----------------------------------------------------------------------------//
+
+// MissingAggregation represents an aggregation of type "missing", as
+// described in https://opensearch.org/docs/latest/aggregations/bucket/missing/
+// Its single bucket holds the documents missing a value for the field.
+type MissingAggregation struct {
+	name  string
+	field string
+	aggs  []Aggregation
+}
+
+// MissingAgg creates a new aggregation of type "missing".
+func MissingAgg(name, field string) *MissingAggregation {
+	return &MissingAggregation{
+		name:  name,
+		field: field,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *MissingAggregation) Name() string {
+	return agg.name
+}
+
+// Aggs sets sub-aggregations for the aggregation.
+func (agg *MissingAggregation) Aggs(aggs ...Aggregation) *MissingAggregation {
+	agg.aggs = aggs
+	return agg
+}
+
+func (agg *MissingAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *MissingAggregation) Map() map[string]interface{} {
+	outerMap := map[string]interface{}{
+		"missing": map[string]interface{}{
+			"field": agg.field,
+		},
+	}
+
+	if len(agg.aggs) > 0 {
+		subAggs := make(map[string]map[string]interface{})
+		for _, sub := range agg.aggs {
+			subAggs[sub.Name()] = sub.Map()
+		}
+		outerMap["aggs"] = subAggs
+	}
+
+	return outerMap
+}
@@ -0,0 +1,31 @@
+package osquery
+
+import "testing"
+
+func TestGlobalAggs(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"global agg",
+			GlobalAgg("all_products").Aggs(Avg("avg_price", "price")),
+			map[string]interface{}{
+				"global": map[string]interface{}{},
+				"aggs": map[string]interface{}{
+					"avg_price": map[string]interface{}{
+						"avg": map[string]interface{}{
+							"field": "price",
+						},
+					},
+				},
+			},
+		},
+		{
+			"missing agg",
+			MissingAgg("no_price", "price"),
+			map[string]interface{}{
+				"missing": map[string]interface{}{
+					"field": "price",
+				},
+			},
+		},
+	})
+}
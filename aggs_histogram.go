@@ -41,6 +41,10 @@ func (agg *HistogramAggregation) Aggs(aggs ...Aggregation) *HistogramAggregation
 	return agg
 }
 
+func (agg *HistogramAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
 // Map builds the OpenSearch aggregation map.
 func (agg *HistogramAggregation) Map() map[string]interface{} {
 	histogramMap := map[string]interface{}{
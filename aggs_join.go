@@ -0,0 +1,71 @@
+package osquery
+
+// JoinAggregation represents an aggregation of type "children" or "parent",
+// as described in
+// https://opensearch.org/docs/latest/aggregations/bucket/children/ and
+// https://opensearch.org/docs/latest/aggregations/bucket/parent/
+// Both are single-bucket aggregations following a join field relation: a
+// "children" aggregation moves from parent documents to their children of
+// the provided type, and a "parent" aggregation from children of the provided
+// type to their parents.
+type JoinAggregation struct {
+	name      string
+	apiName   string
+	childType string
+	aggs      []Aggregation
+}
+
+// ChildrenAgg creates a new aggregation of type "children", on the children
+// of the provided relation name.
+func ChildrenAgg(name, childType string) *JoinAggregation {
+	return &JoinAggregation{
+		name:      name,
+		apiName:   "children",
+		childType: childType,
+	}
+}
+
+// ParentAgg creates a new aggregation of type "parent", on the parents of the
+// children of the provided relation name.
+func ParentAgg(name, childType string) *JoinAggregation {
+	return &JoinAggregation{
+		name:      name,
+		apiName:   "parent",
+		childType: childType,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *JoinAggregation) Name() string {
+	return agg.name
+}
+
+// Aggs sets sub-aggregations for the aggregation.
+func (agg *JoinAggregation) Aggs(aggs ...Aggregation) *JoinAggregation {
+	agg.aggs = aggs
+	return agg
+}
+
+func (agg *JoinAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *JoinAggregation) Map() map[string]interface{} {
+	outerMap := map[string]interface{}{
+		agg.apiName: map[string]interface{}{
+			"type": agg.childType,
+		},
+	}
+
+	if len(agg.aggs) > 0 {
+		subAggs := make(map[string]map[string]interface{})
+		for _, sub := range agg.aggs {
+			subAggs[sub.Name()] = sub.Map()
+		}
+		outerMap["aggs"] = subAggs
+	}
+
+	return outerMap
+}
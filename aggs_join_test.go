@@ -0,0 +1,34 @@
+package osquery
+
+import "testing"
+
+func TestJoinAggs(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"children agg",
+			ChildrenAgg("items", "line_item").
+				Aggs(Sum("quantity", "quantity")),
+			map[string]interface{}{
+				"children": map[string]interface{}{
+					"type": "line_item",
+				},
+				"aggs": map[string]interface{}{
+					"quantity": map[string]interface{}{
+						"sum": map[string]interface{}{
+							"field": "quantity",
+						},
+					},
+				},
+			},
+		},
+		{
+			"parent agg",
+			ParentAgg("orders", "line_item"),
+			map[string]interface{}{
+				"parent": map[string]interface{}{
+					"type": "line_item",
+				},
+			},
+		},
+	})
+}
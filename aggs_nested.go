@@ -36,6 +36,10 @@ func (agg *NestedAggregation) Aggs(aggs ...Aggregation) *NestedAggregation {
 	return agg
 }
 
+func (agg *NestedAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
 func (agg *NestedAggregation) Map() map[string]interface{} {
 	innerMap := map[string]interface{}{
 		"path": agg.path,
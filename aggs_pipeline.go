@@ -0,0 +1,805 @@
+package osquery
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BucketsPath is the path to the metric a pipeline aggregation works on, as
+// described in
+// https://opensearch.org/docs/latest/aggregations/pipeline-agg/#buckets-path-property
+// It is made of aggregation names separated by ">", optionally followed by
+// the name of a metric (e.g. "sales_per_month>sales_stats.avg"). Paths are
+// preferably built with PathTo, but any string may be converted to a
+// BucketsPath.
+type BucketsPath string
+
+const (
+	// PathCount refers to the document count of each bucket.
+	PathCount BucketsPath = "_count"
+
+	// PathKey refers to the key of each bucket.
+	PathKey BucketsPath = "_key"
+)
+
+// PathTo creates a BucketsPath referencing the provided aggregations by name,
+// each aggregation being a sub-aggregation of the previous one.
+func PathTo(aggs ...Aggregation) BucketsPath {
+	names := make([]string, 0, len(aggs))
+	for _, agg := range aggs {
+		names = append(names, agg.Name())
+	}
+	return BucketsPath(strings.Join(names, ">"))
+}
+
+// Metric returns a path to the provided metric of the path's last
+// aggregation, for multi-value metric aggregations such as "stats" (e.g.
+// "avg") or "percentiles" (e.g. "99").
+func (path BucketsPath) Metric(metric string) BucketsPath {
+	return path + "." + BucketsPath(metric)
+}
+
+// Count returns a path to the document count of the buckets of the path's
+// last aggregation.
+func (path BucketsPath) Count() BucketsPath {
+	return path + ">" + PathCount
+}
+
+// BucketCount returns a path to the number of buckets of the path's last
+// aggregation.
+func (path BucketsPath) BucketCount() BucketsPath {
+	return path + ">_bucket_count"
+}
+
+// GapPolicy is an enumeration type for the "gap_policy" option of pipeline
+// aggregations, determining how buckets missing a value are handled.
+type GapPolicy string
+
+const (
+	// GapPolicySkip skips buckets missing a value. This is the default.
+	GapPolicySkip GapPolicy = "skip"
+
+	// GapPolicyInsertZeros replaces missing values with zero.
+	GapPolicyInsertZeros GapPolicy = "insert_zeros"
+
+	// GapPolicyKeepValues is like GapPolicySkip, except that non-null
+	// values computed by sub-aggregations are kept.
+	GapPolicyKeepValues GapPolicy = "keep_values"
+)
+
+// pipelineAggregation is implemented by pipeline aggregations, to allow
+// validating their buckets paths.
+type pipelineAggregation interface {
+	Aggregation
+	bucketsPaths() []BucketsPath
+	// parentPipeline returns whether the aggregation must be a
+	// sub-aggregation of a multi-bucket aggregation, rather than its
+	// sibling.
+	parentPipeline() bool
+}
+
+// histogramPipeline is implemented by parent pipeline aggregations that
+// compute values across consecutive buckets, and thus must be
+// sub-aggregations of a "histogram" or "date_histogram" aggregation.
+type histogramPipeline interface {
+	histogramParent()
+}
+
+// parentAggregation is implemented by bucket aggregations, giving access to
+// their sub-aggregations.
+type parentAggregation interface {
+	subAggs() []Aggregation
+}
+
+// pipelineMap builds the map of a pipeline aggregation of the provided type,
+// adding the options shared by most of them.
+func pipelineMap(
+	aggType string,
+	innerMap map[string]interface{},
+	gapPolicy GapPolicy,
+	format string,
+) map[string]interface{} {
+	if gapPolicy != "" {
+		innerMap["gap_policy"] = gapPolicy
+	}
+	if format != "" {
+		innerMap["format"] = format
+	}
+	return map[string]interface{}{
+		aggType: innerMap,
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// ValidateBucketsPaths verifies that the buckets paths of all pipeline
+// aggregations in the provided aggregation tree reference existing
+// aggregations, and that parent pipeline aggregations are sub-aggregations of
+// bucket aggregations, or of histogram aggregations for "derivative",
+// "cumulative_sum", "moving_fn" and "serial_diff". Paths going through
+// aggregations created via CustomAgg are not verified past them. Search
+// requests are validated automatically before being executed.
+func ValidateBucketsPaths(aggs ...Aggregation) error {
+	return validateAggTree(aggs, nil)
+}
+
+func validateAggTree(aggs []Aggregation, parent Aggregation) error {
+	for _, agg := range aggs {
+		if pipeline, ok := agg.(pipelineAggregation); ok {
+			if pipeline.parentPipeline() && parent == nil {
+				return fmt.Errorf(
+					"pipeline aggregation %q must be a sub-aggregation of a bucket aggregation",
+					agg.Name(),
+				)
+			}
+			if _, ok := agg.(histogramPipeline); ok && !isHistogram(parent) {
+				return fmt.Errorf(
+					"pipeline aggregation %q must be a sub-aggregation of a histogram or date_histogram aggregation",
+					agg.Name(),
+				)
+			}
+			for _, path := range pipeline.bucketsPaths() {
+				if err := resolveBucketsPath(path, aggs); err != nil {
+					return fmt.Errorf("pipeline aggregation %q: %w", agg.Name(), err)
+				}
+			}
+		}
+
+		if bucketAgg, ok := agg.(parentAggregation); ok {
+			if err := validateAggTree(bucketAgg.subAggs(), agg); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func isHistogram(agg Aggregation) bool {
+	switch agg.(type) {
+	case *HistogramAggregation, *DateHistogramAggregation:
+		return true
+	}
+	return false
+}
+
+// resolveBucketsPath verifies that the provided path, relative to the
+// provided sibling aggregations, references existing aggregations.
+func resolveBucketsPath(path BucketsPath, siblings []Aggregation) error {
+	if path == "" {
+		return fmt.Errorf("empty buckets path")
+	}
+
+	elems := strings.Split(string(path), ">")
+	level := siblings
+	for i, elem := range elems {
+		last := i == len(elems)-1
+		if last && isSpecialPath(elem) {
+			return nil
+		}
+
+		name := elem
+		if last {
+			// strip the metric name
+			name, _, _ = strings.Cut(name, ".")
+		}
+		if j := strings.IndexByte(name, '['); j >= 0 {
+			// strip the bucket key of multi-bucket aggregations
+			name = name[:j]
+		}
+
+		agg := findAgg(level, name)
+		if agg == nil {
+			return fmt.Errorf("buckets path %q: no aggregation named %q", path, name)
+		}
+		if last {
+			return nil
+		}
+
+		switch a := agg.(type) {
+		case *CustomAggMap:
+			// the structure of custom aggregations is unknown
+			return nil
+		case parentAggregation:
+			level = a.subAggs()
+		default:
+			return fmt.Errorf("buckets path %q: %q is not a bucket aggregation", path, name)
+		}
+	}
+
+	return nil
+}
+
+func isSpecialPath(elem string) bool {
+	switch elem {
+	case string(PathCount), string(PathKey), "_bucket_count":
+		return true
+	}
+	return false
+}
+
+func findAgg(aggs []Aggregation, name string) Aggregation {
+	for _, agg := range aggs {
+		if agg.Name() == name {
+			return agg
+		}
+	}
+	return nil
+}
+
+//----------------------------------------------------------------------------//
+
+// BucketMetricAgg represents the sibling pipeline aggregations computing a
+// metric over the buckets of another aggregation: "avg_bucket", "sum_bucket",
+// "max_bucket", "min_bucket" and "stats_bucket", as described in
+// https://opensearch.org/docs/latest/aggregations/pipeline-agg/
+type BucketMetricAgg struct {
+	name        string
+	apiName     string
+	bucketsPath BucketsPath
+	gapPolicy   GapPolicy
+	format      string
+}
+
+func newBucketMetricAgg(apiName, name string, path BucketsPath) *BucketMetricAgg {
+	return &BucketMetricAgg{
+		name:        name,
+		apiName:     apiName,
+		bucketsPath: path,
+	}
+}
+
+// AvgBucket creates a new aggregation of type "avg_bucket", computing the
+// average of the metric referenced by path across buckets.
+func AvgBucket(name string, path BucketsPath) *BucketMetricAgg {
+	return newBucketMetricAgg("avg_bucket", name, path)
+}
+
+// SumBucket creates a new aggregation of type "sum_bucket", computing the sum
+// of the metric referenced by path across buckets.
+func SumBucket(name string, path BucketsPath) *BucketMetricAgg {
+	return newBucketMetricAgg("sum_bucket", name, path)
+}
+
+// MaxBucket creates a new aggregation of type "max_bucket", finding the
+// buckets with the maximum value of the metric referenced by path.
+func MaxBucket(name string, path BucketsPath) *BucketMetricAgg {
+	return newBucketMetricAgg("max_bucket", name, path)
+}
+
+// MinBucket creates a new aggregation of type "min_bucket", finding the
+// buckets with the minimum value of the metric referenced by path.
+func MinBucket(name string, path BucketsPath) *BucketMetricAgg {
+	return newBucketMetricAgg("min_bucket", name, path)
+}
+
+// StatsBucket creates a new aggregation of type "stats_bucket", computing
+// statistics of the metric referenced by path across buckets.
+func StatsBucket(name string, path BucketsPath) *BucketMetricAgg {
+	return newBucketMetricAgg("stats_bucket", name, path)
+}
+
+// Name returns the name of the aggregation.
+func (agg *BucketMetricAgg) Name() string {
+	return agg.name
+}
+
+// GapPolicy sets how buckets missing a value are handled.
+func (agg *BucketMetricAgg) GapPolicy(policy GapPolicy) *BucketMetricAgg {
+	agg.gapPolicy = policy
+	return agg
+}
+
+// Format sets the format of the aggregation's "value_as_string".
+func (agg *BucketMetricAgg) Format(format string) *BucketMetricAgg {
+	agg.format = format
+	return agg
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *BucketMetricAgg) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"buckets_path": agg.bucketsPath,
+	}
+	return pipelineMap(agg.apiName, innerMap, agg.gapPolicy, agg.format)
+}
+
+func (agg *BucketMetricAgg) bucketsPaths() []BucketsPath {
+	return []BucketsPath{agg.bucketsPath}
+}
+
+func (agg *BucketMetricAgg) parentPipeline() bool {
+	return false
+}
+
+//----------------------------------------------------------------------------//
+
+// DerivativeAggregation represents an aggregation of type "derivative", as
+// described in
+// https://opensearch.org/docs/latest/aggregations/pipeline-agg/#derivative
+type DerivativeAggregation struct {
+	name        string
+	bucketsPath BucketsPath
+	gapPolicy   GapPolicy
+	format      string
+	unit        string
+}
+
+// DerivativeAgg creates a new aggregation of type "derivative", computing the
+// derivative of the metric referenced by path. It must be a sub-aggregation
+// of a histogram or date histogram aggregation.
+func DerivativeAgg(name string, path BucketsPath) *DerivativeAggregation {
+	return &DerivativeAggregation{
+		name:        name,
+		bucketsPath: path,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *DerivativeAggregation) Name() string {
+	return agg.name
+}
+
+// GapPolicy sets how buckets missing a value are handled.
+func (agg *DerivativeAggregation) GapPolicy(policy GapPolicy) *DerivativeAggregation {
+	agg.gapPolicy = policy
+	return agg
+}
+
+// Format sets the format of the aggregation's "value_as_string".
+func (agg *DerivativeAggregation) Format(format string) *DerivativeAggregation {
+	agg.format = format
+	return agg
+}
+
+// Unit sets the time unit of the derivative, such as "1d", for date
+// histograms. The normalized value is then returned as "normalized_value".
+func (agg *DerivativeAggregation) Unit(unit string) *DerivativeAggregation {
+	agg.unit = unit
+	return agg
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *DerivativeAggregation) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"buckets_path": agg.bucketsPath,
+	}
+	if agg.unit != "" {
+		innerMap["unit"] = agg.unit
+	}
+	return pipelineMap("derivative", innerMap, agg.gapPolicy, agg.format)
+}
+
+func (agg *DerivativeAggregation) bucketsPaths() []BucketsPath {
+	return []BucketsPath{agg.bucketsPath}
+}
+
+func (agg *DerivativeAggregation) parentPipeline() bool {
+	return true
+}
+
+func (agg *DerivativeAggregation) histogramParent() {}
+
+//----------------------------------------------------------------------------//
+
+// CumulativeSumAggregation represents an aggregation of type
+// "cumulative_sum", as described in
+// https://opensearch.org/docs/latest/aggregations/pipeline-agg/#cumulative_sum
+type CumulativeSumAggregation struct {
+	name        string
+	bucketsPath BucketsPath
+	format      string
+}
+
+// CumulativeSumAgg creates a new aggregation of type "cumulative_sum",
+// computing the running total of the metric referenced by path. It must be a
+// sub-aggregation of a histogram or date histogram aggregation.
+func CumulativeSumAgg(name string, path BucketsPath) *CumulativeSumAggregation {
+	return &CumulativeSumAggregation{
+		name:        name,
+		bucketsPath: path,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *CumulativeSumAggregation) Name() string {
+	return agg.name
+}
+
+// Format sets the format of the aggregation's "value_as_string".
+func (agg *CumulativeSumAggregation) Format(format string) *CumulativeSumAggregation {
+	agg.format = format
+	return agg
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *CumulativeSumAggregation) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"buckets_path": agg.bucketsPath,
+	}
+	return pipelineMap("cumulative_sum", innerMap, "", agg.format)
+}
+
+func (agg *CumulativeSumAggregation) bucketsPaths() []BucketsPath {
+	return []BucketsPath{agg.bucketsPath}
+}
+
+func (agg *CumulativeSumAggregation) parentPipeline() bool {
+	return true
+}
+
+func (agg *CumulativeSumAggregation) histogramParent() {}
+
+//----------------------------------------------------------------------------//
+
+// MovingFnAggregation represents an aggregation of type "moving_fn", as
+// described in
+// https://opensearch.org/docs/latest/aggregations/pipeline-agg/#moving_fn
+type MovingFnAggregation struct {
+	name        string
+	bucketsPath BucketsPath
+	window      uint64
+	script      *ScriptField
+	shift       *int64
+	gapPolicy   GapPolicy
+	format      string
+}
+
+// MovingFnAgg creates a new aggregation of type "moving_fn", running the
+// provided script over a sliding window of the metric referenced by path.
+// The script typically calls one of the built-in functions, e.g.
+// "MovingFunctions.unweightedAvg(values)". It must be a sub-aggregation of a
+// histogram or date histogram aggregation.
+func MovingFnAgg(name string, path BucketsPath, window uint64, script *ScriptField) *MovingFnAggregation {
+	return &MovingFnAggregation{
+		name:        name,
+		bucketsPath: path,
+		window:      window,
+		script:      script,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *MovingFnAggregation) Name() string {
+	return agg.name
+}
+
+// Shift shifts the window by the provided number of buckets. With the default
+// of 0, the window ends before the current bucket.
+func (agg *MovingFnAggregation) Shift(shift int64) *MovingFnAggregation {
+	agg.shift = &shift
+	return agg
+}
+
+// GapPolicy sets how buckets missing a value are handled.
+func (agg *MovingFnAggregation) GapPolicy(policy GapPolicy) *MovingFnAggregation {
+	agg.gapPolicy = policy
+	return agg
+}
+
+// Format sets the format of the aggregation's "value_as_string".
+func (agg *MovingFnAggregation) Format(format string) *MovingFnAggregation {
+	agg.format = format
+	return agg
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *MovingFnAggregation) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"buckets_path": agg.bucketsPath,
+		"window":       agg.window,
+	}
+	if agg.script != nil {
+		innerMap["script"] = agg.script.Map()["script"]
+	}
+	if agg.shift != nil {
+		innerMap["shift"] = *agg.shift
+	}
+	return pipelineMap("moving_fn", innerMap, agg.gapPolicy, agg.format)
+}
+
+func (agg *MovingFnAggregation) bucketsPaths() []BucketsPath {
+	return []BucketsPath{agg.bucketsPath}
+}
+
+func (agg *MovingFnAggregation) parentPipeline() bool {
+	return true
+}
+
+func (agg *MovingFnAggregation) histogramParent() {}
+
+//----------------------------------------------------------------------------//
+
+// SerialDiffAggregation represents an aggregation of type "serial_diff", as
+// described in
+// https://opensearch.org/docs/latest/aggregations/pipeline-agg/#serial_diff
+type SerialDiffAggregation struct {
+	name        string
+	bucketsPath BucketsPath
+	lag         *uint64
+	gapPolicy   GapPolicy
+	format      string
+}
+
+// SerialDiffAgg creates a new aggregation of type "serial_diff", subtracting
+// from the metric referenced by path its value in a previous bucket. It must
+// be a sub-aggregation of a histogram or date histogram aggregation.
+func SerialDiffAgg(name string, path BucketsPath) *SerialDiffAggregation {
+	return &SerialDiffAggregation{
+		name:        name,
+		bucketsPath: path,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *SerialDiffAggregation) Name() string {
+	return agg.name
+}
+
+// Lag sets how many buckets back the subtracted value is taken from. The
+// default is 1.
+func (agg *SerialDiffAggregation) Lag(lag uint64) *SerialDiffAggregation {
+	agg.lag = &lag
+	return agg
+}
+
+// GapPolicy sets how buckets missing a value are handled.
+func (agg *SerialDiffAggregation) GapPolicy(policy GapPolicy) *SerialDiffAggregation {
+	agg.gapPolicy = policy
+	return agg
+}
+
+// Format sets the format of the aggregation's "value_as_string".
+func (agg *SerialDiffAggregation) Format(format string) *SerialDiffAggregation {
+	agg.format = format
+	return agg
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *SerialDiffAggregation) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"buckets_path": agg.bucketsPath,
+	}
+	if agg.lag != nil {
+		innerMap["lag"] = *agg.lag
+	}
+	return pipelineMap("serial_diff", innerMap, agg.gapPolicy, agg.format)
+}
+
+func (agg *SerialDiffAggregation) bucketsPaths() []BucketsPath {
+	return []BucketsPath{agg.bucketsPath}
+}
+
+func (agg *SerialDiffAggregation) parentPipeline() bool {
+	return true
+}
+
+func (agg *SerialDiffAggregation) histogramParent() {}
+
+//----------------------------------------------------------------------------//
+
+// BucketScriptAggregation represents an aggregation of type "bucket_script",
+// as described in
+// https://opensearch.org/docs/latest/aggregations/pipeline-agg/#bucket_script-bucket_selector
+type BucketScriptAggregation struct {
+	name        string
+	bucketsPath map[string]BucketsPath
+	script      *ScriptField
+	gapPolicy   GapPolicy
+	format      string
+}
+
+// BucketScriptAgg creates a new aggregation of type "bucket_script", running
+// the provided script in every bucket of its parent aggregation. The metrics
+// the script uses are set via BucketsPath.
+func BucketScriptAgg(name string, script *ScriptField) *BucketScriptAggregation {
+	return &BucketScriptAggregation{
+		name:        name,
+		bucketsPath: make(map[string]BucketsPath),
+		script:      script,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *BucketScriptAggregation) Name() string {
+	return agg.name
+}
+
+// BucketsPath makes the metric referenced by path available to the script as
+// params.<variable>.
+func (agg *BucketScriptAggregation) BucketsPath(variable string, path BucketsPath) *BucketScriptAggregation {
+	agg.bucketsPath[variable] = path
+	return agg
+}
+
+// GapPolicy sets how buckets missing a value are handled.
+func (agg *BucketScriptAggregation) GapPolicy(policy GapPolicy) *BucketScriptAggregation {
+	agg.gapPolicy = policy
+	return agg
+}
+
+// Format sets the format of the aggregation's "value_as_string".
+func (agg *BucketScriptAggregation) Format(format string) *BucketScriptAggregation {
+	agg.format = format
+	return agg
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *BucketScriptAggregation) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"buckets_path": agg.bucketsPath,
+	}
+	if agg.script != nil {
+		innerMap["script"] = agg.script.Map()["script"]
+	}
+	return pipelineMap("bucket_script", innerMap, agg.gapPolicy, agg.format)
+}
+
+func (agg *BucketScriptAggregation) bucketsPaths() []BucketsPath {
+	return pathValues(agg.bucketsPath)
+}
+
+func (agg *BucketScriptAggregation) parentPipeline() bool {
+	return true
+}
+
+// pathValues returns the paths of a buckets_path map.
+func pathValues(paths map[string]BucketsPath) []BucketsPath {
+	values := make([]BucketsPath, 0, len(paths))
+	for _, path := range paths {
+		values = append(values, path)
+	}
+	return values
+}
+
+//----------------------------------------------------------------------------//
+
+// BucketSelectorAggregation represents an aggregation of type
+// "bucket_selector", as described in
+// https://opensearch.org/docs/latest/aggregations/pipeline-agg/#bucket_script-bucket_selector
+type BucketSelectorAggregation struct {
+	name        string
+	bucketsPath map[string]BucketsPath
+	script      *ScriptField
+	gapPolicy   GapPolicy
+}
+
+// BucketSelectorAgg creates a new aggregation of type "bucket_selector",
+// only keeping the buckets of its parent aggregation for which the provided
+// script returns true. The metrics the script uses are set via BucketsPath.
+func BucketSelectorAgg(name string, script *ScriptField) *BucketSelectorAggregation {
+	return &BucketSelectorAggregation{
+		name:        name,
+		bucketsPath: make(map[string]BucketsPath),
+		script:      script,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *BucketSelectorAggregation) Name() string {
+	return agg.name
+}
+
+// BucketsPath makes the metric referenced by path available to the script as
+// params.<variable>.
+func (agg *BucketSelectorAggregation) BucketsPath(variable string, path BucketsPath) *BucketSelectorAggregation {
+	agg.bucketsPath[variable] = path
+	return agg
+}
+
+// GapPolicy sets how buckets missing a value are handled.
+func (agg *BucketSelectorAggregation) GapPolicy(policy GapPolicy) *BucketSelectorAggregation {
+	agg.gapPolicy = policy
+	return agg
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *BucketSelectorAggregation) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"buckets_path": agg.bucketsPath,
+	}
+	if agg.script != nil {
+		innerMap["script"] = agg.script.Map()["script"]
+	}
+	return pipelineMap("bucket_selector", innerMap, agg.gapPolicy, "")
+}
+
+func (agg *BucketSelectorAggregation) bucketsPaths() []BucketsPath {
+	return pathValues(agg.bucketsPath)
+}
+
+func (agg *BucketSelectorAggregation) parentPipeline() bool {
+	return true
+}
+
+//----------------------------------------------------------------------------//
+
+// BucketSortAggregation represents an aggregation of type "bucket_sort", as
+// described in
+// https://opensearch.org/docs/latest/aggregations/pipeline-agg/#bucket_sort
+type BucketSortAggregation struct {
+	name      string
+	sort      []SortOption
+	from      *uint64
+	size      *uint64
+	gapPolicy GapPolicy
+}
+
+// BucketSortAgg creates a new aggregation of type "bucket_sort", sorting and
+// truncating the buckets of its parent aggregation.
+func BucketSortAgg(name string) *BucketSortAggregation {
+	return &BucketSortAggregation{
+		name: name,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *BucketSortAggregation) Name() string {
+	return agg.name
+}
+
+// Sort appends sort options, whose fields are buckets paths, e.g.
+// FieldSort("total_sales").Order(OrderDesc).
+func (agg *BucketSortAggregation) Sort(sort ...SortOption) *BucketSortAggregation {
+	agg.sort = append(agg.sort, sort...)
+	return agg
+}
+
+// From sets the number of buckets to skip.
+func (agg *BucketSortAggregation) From(from uint64) *BucketSortAggregation {
+	agg.from = &from
+	return agg
+}
+
+// Size sets the number of buckets to return.
+func (agg *BucketSortAggregation) Size(size uint64) *BucketSortAggregation {
+	agg.size = &size
+	return agg
+}
+
+// GapPolicy sets how buckets missing a value are handled.
+func (agg *BucketSortAggregation) GapPolicy(policy GapPolicy) *BucketSortAggregation {
+	agg.gapPolicy = policy
+	return agg
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *BucketSortAggregation) Map() map[string]interface{} {
+	innerMap := make(map[string]interface{})
+	if len(agg.sort) > 0 {
+		sort := make([]map[string]interface{}, 0, len(agg.sort))
+		for _, s := range agg.sort {
+			sort = append(sort, s.Map())
+		}
+		innerMap["sort"] = sort
+	}
+	if agg.from != nil {
+		innerMap["from"] = *agg.from
+	}
+	if agg.size != nil {
+		innerMap["size"] = *agg.size
+	}
+	return pipelineMap("bucket_sort", innerMap, agg.gapPolicy, "")
+}
+
+func (agg *BucketSortAggregation) bucketsPaths() []BucketsPath {
+	var paths []BucketsPath
+	for _, s := range agg.sort {
+		for key := range s.Map() {
+			paths = append(paths, BucketsPath(key))
+		}
+	}
+	return paths
+}
+
+func (agg *BucketSortAggregation) parentPipeline() bool {
+	return true
+}
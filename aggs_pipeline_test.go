@@ -0,0 +1,222 @@
+package osquery
+
+import (
+	"testing"
+
+	"github.com/jgroeneveld/trial/assert"
+)
+
+func TestPipelineAggs(t *testing.T) {
+	sales := Sum("sales", "price")
+	stats := Stats("sales_stats", "price")
+	perMonth := DateHistogramAgg("sales_per_month", "date").
+		CalendarInterval("month").
+		Aggs(sales, stats)
+
+	runMapTests(t, []mapTest{
+		{
+			"avg_bucket",
+			AvgBucket("avg_monthly_sales", PathTo(perMonth, sales)).
+				GapPolicy(GapPolicyInsertZeros).
+				Format("#,##0.00"),
+			map[string]interface{}{
+				"avg_bucket": map[string]interface{}{
+					"buckets_path": "sales_per_month>sales",
+					"gap_policy":   "insert_zeros",
+					"format":       "#,##0.00",
+				},
+			},
+		},
+		{
+			"stats_bucket with a metric",
+			StatsBucket("stats_monthly_sales", PathTo(perMonth, stats).Metric("avg")),
+			map[string]interface{}{
+				"stats_bucket": map[string]interface{}{
+					"buckets_path": "sales_per_month>sales_stats.avg",
+				},
+			},
+		},
+		{
+			"max_bucket on the document count",
+			MaxBucket("busiest_month", PathTo(perMonth).Count()),
+			map[string]interface{}{
+				"max_bucket": map[string]interface{}{
+					"buckets_path": "sales_per_month>_count",
+				},
+			},
+		},
+		{
+			"derivative",
+			DerivativeAgg("sales_deriv", PathTo(sales)).Unit("1d"),
+			map[string]interface{}{
+				"derivative": map[string]interface{}{
+					"buckets_path": "sales",
+					"unit":         "1d",
+				},
+			},
+		},
+		{
+			"cumulative_sum",
+			CumulativeSumAgg("cumulative_sales", PathTo(sales)).Format("0.0"),
+			map[string]interface{}{
+				"cumulative_sum": map[string]interface{}{
+					"buckets_path": "sales",
+					"format":       "0.0",
+				},
+			},
+		},
+		{
+			"moving_fn",
+			MovingFnAgg("moving_avg", PathTo(sales), 10,
+				Script("").Source("MovingFunctions.unweightedAvg(values)"),
+			).Shift(1),
+			map[string]interface{}{
+				"moving_fn": map[string]interface{}{
+					"buckets_path": "sales",
+					"window":       10,
+					"shift":        1,
+					"script": map[string]interface{}{
+						"source": "MovingFunctions.unweightedAvg(values)",
+					},
+				},
+			},
+		},
+		{
+			"serial_diff",
+			SerialDiffAgg("yearly_diff", PathCount).Lag(12).GapPolicy(GapPolicySkip),
+			map[string]interface{}{
+				"serial_diff": map[string]interface{}{
+					"buckets_path": "_count",
+					"lag":          12,
+					"gap_policy":   "skip",
+				},
+			},
+		},
+		{
+			"bucket_script",
+			BucketScriptAgg("sales_per_doc", Script("").Source("params.sales / params.docs")).
+				BucketsPath("sales", PathTo(sales)).
+				BucketsPath("docs", PathCount),
+			map[string]interface{}{
+				"bucket_script": map[string]interface{}{
+					"buckets_path": map[string]interface{}{
+						"sales": "sales",
+						"docs":  "_count",
+					},
+					"script": map[string]interface{}{
+						"source": "params.sales / params.docs",
+					},
+				},
+			},
+		},
+		{
+			"bucket_selector",
+			BucketSelectorAgg("big_months", Script("").Source("params.sales > 200")).
+				BucketsPath("sales", PathTo(sales)),
+			map[string]interface{}{
+				"bucket_selector": map[string]interface{}{
+					"buckets_path": map[string]interface{}{
+						"sales": "sales",
+					},
+					"script": map[string]interface{}{
+						"source": "params.sales > 200",
+					},
+				},
+			},
+		},
+		{
+			"bucket_sort",
+			BucketSortAgg("top_months").
+				Sort(FieldSort("sales").Order(OrderDesc)).
+				From(1).
+				Size(3),
+			map[string]interface{}{
+				"bucket_sort": map[string]interface{}{
+					"sort": []map[string]interface{}{
+						{"sales": map[string]interface{}{"order": "desc"}},
+					},
+					"from": 1,
+					"size": 3,
+				},
+			},
+		},
+	})
+}
+
+func TestValidateBucketsPaths(t *testing.T) {
+	sales := Sum("sales", "price")
+	stats := Stats("sales_stats", "price")
+	perMonth := DateHistogramAgg("sales_per_month", "date").
+		CalendarInterval("month").
+		Aggs(
+			sales,
+			stats,
+			DerivativeAgg("sales_deriv", PathTo(sales)),
+			BucketSortAgg("sort").Sort(FieldSort("sales").Order(OrderDesc), FieldSort("_key")),
+		)
+
+	t.Run("valid paths", func(t *testing.T) {
+		assert.Nil(t, ValidateBucketsPaths(
+			perMonth,
+			AvgBucket("avg_sales", PathTo(perMonth, sales)),
+			StatsBucket("stats", PathTo(perMonth, stats).Metric("avg")),
+			MaxBucket("busiest", PathTo(perMonth).Count()),
+			SumBucket("custom_sum", "custom>anything.value"),
+			CustomAgg("custom", map[string]interface{}{}),
+		))
+	})
+
+	t.Run("unknown aggregation", func(t *testing.T) {
+		err := ValidateBucketsPaths(perMonth, AvgBucket("avg_sales", "sales_per_month>nope"))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("unknown nested aggregation", func(t *testing.T) {
+		bad := DateHistogramAgg("per_day", "date").
+			CalendarInterval("day").
+			Aggs(CumulativeSumAgg("cumsum", "missing"))
+		assert.NotNil(t, ValidateBucketsPaths(bad))
+	})
+
+	t.Run("path through a metric aggregation", func(t *testing.T) {
+		err := ValidateBucketsPaths(sales, AvgBucket("avg", "sales>value"))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("top-level parent pipeline", func(t *testing.T) {
+		err := ValidateBucketsPaths(sales, DerivativeAgg("deriv", PathTo(sales)))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("histogram pipeline under a single-bucket aggregation", func(t *testing.T) {
+		for _, agg := range []Aggregation{
+			DerivativeAgg("deriv", PathTo(sales)),
+			CumulativeSumAgg("cumsum", PathTo(sales)),
+			MovingFnAgg("moving", PathTo(sales), 5, Script("").Source("MovingFunctions.max(values)")),
+			SerialDiffAgg("diff", PathTo(sales)),
+		} {
+			err := ValidateBucketsPaths(FilterAgg("recent", Term("recent", true)).Aggs(sales, agg))
+			assert.NotNil(t, err)
+		}
+	})
+
+	t.Run("histogram pipeline under a terms aggregation", func(t *testing.T) {
+		err := ValidateBucketsPaths(TermsAgg("by_shop", "shop").Aggs(sales, DerivativeAgg("deriv", PathTo(sales))))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("bucket script under a terms aggregation", func(t *testing.T) {
+		err := ValidateBucketsPaths(TermsAgg("by_shop", "shop").Aggs(
+			sales,
+			BucketScriptAgg("double", Script("").Source("params.s * 2")).BucketsPath("s", PathTo(sales)),
+		))
+		assert.Nil(t, err)
+	})
+
+	t.Run("search requests are validated", func(t *testing.T) {
+		_, err := Search().
+			Aggs(perMonth, AvgBucket("avg_sales", "sales_per_month>nope")).
+			searchReq(nil)
+		assert.NotNil(t, err)
+	})
+}
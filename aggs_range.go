@@ -0,0 +1,329 @@
+package osquery
+
+// aggRange is a single range of a range aggregation. A nil from or to leaves
+// the range unbounded on that side.
+type aggRange struct {
+	key  string
+	from interface{}
+	to   interface{}
+	mask string
+}
+
+// Map returns a map representation of the range.
+func (r aggRange) Map() map[string]interface{} {
+	m := make(map[string]interface{})
+	if r.key != "" {
+		m["key"] = r.key
+	}
+	if r.mask != "" {
+		m["mask"] = r.mask
+	}
+	if r.from != nil {
+		m["from"] = r.from
+	}
+	if r.to != nil {
+		m["to"] = r.to
+	}
+	return m
+}
+
+// rangeAggMap builds the map of a range aggregation of the provided type,
+// shared by all range aggregation builders.
+func rangeAggMap(
+	aggType string,
+	innerMap map[string]interface{},
+	ranges []aggRange,
+	keyed *bool,
+	aggs []Aggregation,
+) map[string]interface{} {
+	rangeMaps := make([]map[string]interface{}, 0, len(ranges))
+	for _, r := range ranges {
+		rangeMaps = append(rangeMaps, r.Map())
+	}
+	innerMap["ranges"] = rangeMaps
+	if keyed != nil {
+		innerMap["keyed"] = *keyed
+	}
+
+	outerMap := map[string]interface{}{
+		aggType: innerMap,
+	}
+	if len(aggs) > 0 {
+		subAggs := make(map[string]map[string]interface{})
+		for _, sub := range aggs {
+			subAggs[sub.Name()] = sub.Map()
+		}
+		outerMap["aggs"] = subAggs
+	}
+
+	return outerMap
+}
+
+//----------------------------------------------------------------------------//
+
+// RangeAggregation represents an aggregation of type "range", as described
+// in https://opensearch.org/docs/latest/aggregations/bucket/range/
+type RangeAggregation struct {
+	name   string
+	field  string
+	ranges []aggRange
+	keyed  *bool
+	format string
+	aggs   []Aggregation
+}
+
+// RangeAgg creates a new aggregation of type "range". The method name
+// includes the "Agg" suffix to prevent conflict with the "range" query.
+func RangeAgg(name, field string) *RangeAggregation {
+	return &RangeAggregation{
+		name:  name,
+		field: field,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *RangeAggregation) Name() string {
+	return agg.name
+}
+
+// AddRange adds a range from "from" (inclusive) to "to" (exclusive). Either
+// may be nil to leave the range unbounded on that side.
+func (agg *RangeAggregation) AddRange(from, to interface{}) *RangeAggregation {
+	agg.ranges = append(agg.ranges, aggRange{from: from, to: to})
+	return agg
+}
+
+// AddKeyedRange adds a range like AddRange, naming its bucket with the
+// provided key.
+func (agg *RangeAggregation) AddKeyedRange(key string, from, to interface{}) *RangeAggregation {
+	agg.ranges = append(agg.ranges, aggRange{key: key, from: from, to: to})
+	return agg
+}
+
+// Keyed sets whether buckets are returned as an object keyed by the bucket
+// keys rather than as an array.
+func (agg *RangeAggregation) Keyed(b bool) *RangeAggregation {
+	agg.keyed = &b
+	return agg
+}
+
+// Format sets the format of the buckets' "from_as_string" and "to_as_string".
+func (agg *RangeAggregation) Format(format string) *RangeAggregation {
+	agg.format = format
+	return agg
+}
+
+// Aggs sets sub-aggregations for the aggregation.
+func (agg *RangeAggregation) Aggs(aggs ...Aggregation) *RangeAggregation {
+	agg.aggs = aggs
+	return agg
+}
+
+func (agg *RangeAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *RangeAggregation) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"field": agg.field,
+	}
+	if agg.format != "" {
+		innerMap["format"] = agg.format
+	}
+
+	return rangeAggMap("range", innerMap, agg.ranges, agg.keyed, agg.aggs)
+}
+
+//----------------------------------------------------------------------------//
+
+// DateRangeAggregation represents an aggregation of type "date_range", as
+// described in
+// https://opensearch.org/docs/latest/aggregations/bucket/date-range/
+type DateRangeAggregation struct {
+	name     string
+	field    string
+	ranges   []aggRange
+	keyed    *bool
+	format   string
+	timeZone string
+	missing  interface{}
+	aggs     []Aggregation
+}
+
+// DateRangeAgg creates a new aggregation of type "date_range".
+func DateRangeAgg(name, field string) *DateRangeAggregation {
+	return &DateRangeAggregation{
+		name:  name,
+		field: field,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *DateRangeAggregation) Name() string {
+	return agg.name
+}
+
+// AddRange adds a range from "from" (inclusive) to "to" (exclusive), which
+// may be dates or date math expressions such as "now-1M/M". Either may be nil
+// to leave the range unbounded on that side.
+func (agg *DateRangeAggregation) AddRange(from, to interface{}) *DateRangeAggregation {
+	agg.ranges = append(agg.ranges, aggRange{from: from, to: to})
+	return agg
+}
+
+// AddKeyedRange adds a range like AddRange, naming its bucket with the
+// provided key.
+func (agg *DateRangeAggregation) AddKeyedRange(key string, from, to interface{}) *DateRangeAggregation {
+	agg.ranges = append(agg.ranges, aggRange{key: key, from: from, to: to})
+	return agg
+}
+
+// Keyed sets whether buckets are returned as an object keyed by the bucket
+// keys rather than as an array.
+func (agg *DateRangeAggregation) Keyed(b bool) *DateRangeAggregation {
+	agg.keyed = &b
+	return agg
+}
+
+// Format sets the date format used to parse the ranges and to format the
+// buckets' "from_as_string" and "to_as_string".
+func (agg *DateRangeAggregation) Format(format string) *DateRangeAggregation {
+	agg.format = format
+	return agg
+}
+
+// TimeZone sets the time zone used to resolve dates and date math in the
+// ranges.
+func (agg *DateRangeAggregation) TimeZone(tz string) *DateRangeAggregation {
+	agg.timeZone = tz
+	return agg
+}
+
+// Missing sets the value used for documents missing the field.
+func (agg *DateRangeAggregation) Missing(missing interface{}) *DateRangeAggregation {
+	agg.missing = missing
+	return agg
+}
+
+// Aggs sets sub-aggregations for the aggregation.
+func (agg *DateRangeAggregation) Aggs(aggs ...Aggregation) *DateRangeAggregation {
+	agg.aggs = aggs
+	return agg
+}
+
+func (agg *DateRangeAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *DateRangeAggregation) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"field": agg.field,
+	}
+	if agg.format != "" {
+		innerMap["format"] = agg.format
+	}
+	if agg.timeZone != "" {
+		innerMap["time_zone"] = agg.timeZone
+	}
+	if agg.missing != nil {
+		innerMap["missing"] = agg.missing
+	}
+
+	return rangeAggMap("date_range", innerMap, agg.ranges, agg.keyed, agg.aggs)
+}
+
+//----------------------------------------------------------------------------//
+
+// IPRangeAggregation represents an aggregation of type "ip_range", as
+// described in
+// https://opensearch.org/docs/latest/aggregations/bucket/ip-range/
+type IPRangeAggregation struct {
+	name   string
+	field  string
+	ranges []aggRange
+	keyed  *bool
+	aggs   []Aggregation
+}
+
+// IPRangeAgg creates a new aggregation of type "ip_range".
+func IPRangeAgg(name, field string) *IPRangeAggregation {
+	return &IPRangeAggregation{
+		name:  name,
+		field: field,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *IPRangeAggregation) Name() string {
+	return agg.name
+}
+
+// AddRange adds a range of IP addresses from "from" (inclusive) to "to"
+// (exclusive). Either may be empty to leave the range unbounded on that side.
+func (agg *IPRangeAggregation) AddRange(from, to string) *IPRangeAggregation {
+	agg.ranges = append(agg.ranges, ipRange("", from, to))
+	return agg
+}
+
+// AddKeyedRange adds a range like AddRange, naming its bucket with the
+// provided key.
+func (agg *IPRangeAggregation) AddKeyedRange(key, from, to string) *IPRangeAggregation {
+	agg.ranges = append(agg.ranges, ipRange(key, from, to))
+	return agg
+}
+
+// AddMask adds a range of IP addresses defined by a CIDR mask, such as
+// "10.0.0.0/24".
+func (agg *IPRangeAggregation) AddMask(mask string) *IPRangeAggregation {
+	agg.ranges = append(agg.ranges, aggRange{mask: mask})
+	return agg
+}
+
+// AddKeyedMask adds a range like AddMask, naming its bucket with the provided
+// key.
+func (agg *IPRangeAggregation) AddKeyedMask(key, mask string) *IPRangeAggregation {
+	agg.ranges = append(agg.ranges, aggRange{key: key, mask: mask})
+	return agg
+}
+
+// Keyed sets whether buckets are returned as an object keyed by the bucket
+// keys rather than as an array.
+func (agg *IPRangeAggregation) Keyed(b bool) *IPRangeAggregation {
+	agg.keyed = &b
+	return agg
+}
+
+// Aggs sets sub-aggregations for the aggregation.
+func (agg *IPRangeAggregation) Aggs(aggs ...Aggregation) *IPRangeAggregation {
+	agg.aggs = aggs
+	return agg
+}
+
+func (agg *IPRangeAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *IPRangeAggregation) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"field": agg.field,
+	}
+
+	return rangeAggMap("ip_range", innerMap, agg.ranges, agg.keyed, agg.aggs)
+}
+
+func ipRange(key, from, to string) aggRange {
+	r := aggRange{key: key}
+	if from != "" {
+		r.from = from
+	}
+	if to != "" {
+		r.to = to
+	}
+	return r
+}
@@ -0,0 +1,94 @@
+package osquery
+
+import "testing"
+
+func TestRangeAggs(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"range agg: unbounded ranges",
+			RangeAgg("price_bands", "price").
+				AddRange(nil, 100).
+				AddRange(100, 200).
+				AddRange(200, nil),
+			map[string]interface{}{
+				"range": map[string]interface{}{
+					"field": "price",
+					"ranges": []map[string]interface{}{
+						{"to": 100},
+						{"from": 100, "to": 200},
+						{"from": 200},
+					},
+				},
+			},
+		},
+		{
+			"range agg: keyed with sub-aggs",
+			RangeAgg("price_bands", "price").
+				AddKeyedRange("cheap", nil, 100).
+				AddKeyedRange("expensive", 100, nil).
+				Keyed(true).
+				Format("#.00").
+				Aggs(Avg("avg_rating", "rating")),
+			map[string]interface{}{
+				"range": map[string]interface{}{
+					"field":  "price",
+					"keyed":  true,
+					"format": "#.00",
+					"ranges": []map[string]interface{}{
+						{"key": "cheap", "to": 100},
+						{"key": "expensive", "from": 100},
+					},
+				},
+				"aggs": map[string]interface{}{
+					"avg_rating": map[string]interface{}{
+						"avg": map[string]interface{}{
+							"field": "rating",
+						},
+					},
+				},
+			},
+		},
+		{
+			"date_range agg",
+			DateRangeAgg("periods", "published_at").
+				AddKeyedRange("older", nil, "now-1M/M").
+				AddKeyedRange("recent", "now-1M/M", nil).
+				Format("MM-yyyy").
+				TimeZone("Europe/Paris").
+				Missing("1970-01-01"),
+			map[string]interface{}{
+				"date_range": map[string]interface{}{
+					"field":     "published_at",
+					"format":    "MM-yyyy",
+					"time_zone": "Europe/Paris",
+					"missing":   "1970-01-01",
+					"ranges": []map[string]interface{}{
+						{"key": "older", "to": "now-1M/M"},
+						{"key": "recent", "from": "now-1M/M"},
+					},
+				},
+			},
+		},
+		{
+			"ip_range agg",
+			IPRangeAgg("networks", "ip").
+				AddRange("", "10.0.0.5").
+				AddKeyedRange("upper", "10.0.0.5", "").
+				AddMask("10.0.0.0/25").
+				AddKeyedMask("lan", "192.168.0.0/16").
+				Keyed(false),
+			map[string]interface{}{
+				"ip_range": map[string]interface{}{
+					"field": "ip",
+					"keyed": false,
+					"ranges": []map[string]interface{}{
+						{"to": "10.0.0.5"},
+						{"key": "upper", "from": "10.0.0.5"},
+						{"mask": "10.0.0.0/25"},
+						{"key": "lan", "mask": "192.168.0.0/16"},
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,774 @@
+package osquery
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AggregationResults holds the "aggregations" section of a search response,
+// keyed by aggregation name. Results are decoded lazily into the companion
+// result type of each aggregation builder, by passing the same Aggregation
+// value that was used to build the request to the matching lookup method:
+//
+//	avgScore := osquery.Avg("avg_score", "score")
+//	terms := osquery.TermsAgg("by_tag", "tag").Aggs(avgScore)
+//	...
+//	res, err := results.Terms(terms)
+//	for _, bucket := range res.Buckets {
+//		avg, err := bucket.Aggregations.Avg(avgScore)
+//	}
+//
+// Sub-aggregation results of bucket aggregations are exposed as
+// AggregationResults as well, so lookups recurse naturally through the
+// aggregation tree. Lookups return ErrAggregationNotFound if the response
+// holds no result for the aggregation, and a decoding error if the result is
+// malformed.
+type AggregationResults map[string]json.RawMessage
+
+// ParseAggregations parses the raw "aggregations" section of a response, as
+// returned by SearchRequest.Run in opensearchapi.SearchResp.Aggregations.
+func ParseAggregations(raw json.RawMessage) (AggregationResults, error) {
+	aggs := make(AggregationResults)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return aggs, nil
+	}
+	if err := json.Unmarshal(raw, &aggs); err != nil {
+		return nil, err
+	}
+	return aggs, nil
+}
+
+// ErrAggregationNotFound is returned by the lookup methods of
+// AggregationResults when the response holds no result for the aggregation.
+var ErrAggregationNotFound = errors.New("aggregation not found")
+
+// decodeResult unmarshals the result of the provided aggregation. It returns
+// ErrAggregationNotFound if the result is missing.
+func decodeResult[T any](aggs AggregationResults, agg Aggregation) (*T, error) {
+	if aggs == nil || agg == nil {
+		return nil, ErrAggregationNotFound
+	}
+	raw, ok := aggs[agg.Name()]
+	if !ok {
+		return nil, ErrAggregationNotFound
+	}
+	var res T
+	if err := json.Unmarshal(raw, &res); err != nil {
+		return nil, fmt.Errorf("failed to decode aggregation %q: %w", agg.Name(), err)
+	}
+	return &res, nil
+}
+
+// Raw returns the undecoded result of the provided aggregation. It is useful
+// for aggregations created via CustomAgg.
+func (aggs AggregationResults) Raw(agg Aggregation) (json.RawMessage, bool) {
+	if aggs == nil || agg == nil {
+		return nil, false
+	}
+	raw, ok := aggs[agg.Name()]
+	return raw, ok
+}
+
+//----------------------------------------------------------------------------//
+
+// MetricValueResult is the result of single-value metric aggregations such as
+// "avg", "sum", "min", "max", "cardinality", "value_count" and "weighted_avg".
+// Value is nil if no documents had a value for the aggregated field.
+type MetricValueResult struct {
+	Value         *float64 `json:"value"`
+	ValueAsString string   `json:"value_as_string,omitempty"`
+}
+
+// StatsResult is the result of a "stats" aggregation.
+type StatsResult struct {
+	Count       int64    `json:"count"`
+	Min         *float64 `json:"min"`
+	Max         *float64 `json:"max"`
+	Avg         *float64 `json:"avg"`
+	Sum         float64  `json:"sum"`
+	MinAsString string   `json:"min_as_string,omitempty"`
+	MaxAsString string   `json:"max_as_string,omitempty"`
+	AvgAsString string   `json:"avg_as_string,omitempty"`
+	SumAsString string   `json:"sum_as_string,omitempty"`
+}
+
+// StringStatsResult is the result of a "string_stats" aggregation.
+type StringStatsResult struct {
+	Count        int64              `json:"count"`
+	MinLength    *int64             `json:"min_length"`
+	MaxLength    *int64             `json:"max_length"`
+	AvgLength    *float64           `json:"avg_length"`
+	Entropy      float64            `json:"entropy"`
+	Distribution map[string]float64 `json:"distribution,omitempty"`
+}
+
+// PercentilesResult is the result of a "percentiles" aggregation.
+type PercentilesResult struct {
+	Values []PercentileValue
+}
+
+// PercentileValue is a single percentile of a PercentilesResult. Value is nil
+// if no documents had a value for the aggregated field.
+type PercentileValue struct {
+	Percent       float64  `json:"key"`
+	Value         *float64 `json:"value"`
+	ValueAsString string   `json:"value_as_string,omitempty"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Both the keyed
+// (default) and non-keyed response formats are supported.
+func (res *PercentilesResult) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Values json.RawMessage `json:"values"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	res.Values = nil
+	if len(raw.Values) == 0 {
+		return nil
+	}
+
+	if raw.Values[0] == '[' {
+		return json.Unmarshal(raw.Values, &res.Values)
+	}
+
+	var keyed map[string]json.RawMessage
+	if err := json.Unmarshal(raw.Values, &keyed); err != nil {
+		return err
+	}
+	for key, data := range keyed {
+		if strings.HasSuffix(key, "_as_string") {
+			// merged into the value of the matching percent
+			continue
+		}
+		percent, err := strconv.ParseFloat(key, 64)
+		if err != nil {
+			continue
+		}
+		val := PercentileValue{Percent: percent}
+		if err := json.Unmarshal(data, &val.Value); err != nil {
+			return fmt.Errorf("failed to decode percentile %q: %w", key, err)
+		}
+		if str, ok := keyed[key+"_as_string"]; ok {
+			if err := json.Unmarshal(str, &val.ValueAsString); err != nil {
+				return fmt.Errorf("failed to decode percentile %q: %w", key+"_as_string", err)
+			}
+		}
+		res.Values = append(res.Values, val)
+	}
+	sort.Slice(res.Values, func(i, j int) bool {
+		return res.Values[i].Percent < res.Values[j].Percent
+	})
+
+	return nil
+}
+
+// Percentile returns the value of the provided percent, if it was returned by
+// OpenSearch and is not null.
+func (res *PercentilesResult) Percentile(percent float64) (float64, bool) {
+	for _, val := range res.Values {
+		if val.Percent == percent && val.Value != nil {
+			return *val.Value, true
+		}
+	}
+	return 0, false
+}
+
+// TopHitsResult is the result of a "top_hits" aggregation. The "_source" of
+// each hit is kept raw, as it may not share the shape of the top level hits.
+type TopHitsResult struct {
+	Hits SearchHits[json.RawMessage] `json:"hits"`
+}
+
+//----------------------------------------------------------------------------//
+
+// Bucket is a single bucket of a multi-bucket aggregation result. Results of
+// the bucket's sub-aggregations are available in Aggregations. Numeric keys
+// are decoded as json.Number, so that long keys do not lose precision.
+type Bucket struct {
+	Key                     interface{}
+	KeyAsString             string
+	DocCount                int64
+	DocCountErrorUpperBound *int64
+	Aggregations            AggregationResults
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (b *Bucket) UnmarshalJSON(data []byte) (err error) {
+	var key json.RawMessage
+	b.Aggregations, err = decodeBucket(data, map[string]interface{}{
+		"key":                         &key,
+		"key_as_string":               &b.KeyAsString,
+		"doc_count":                   &b.DocCount,
+		"doc_count_error_upper_bound": &b.DocCountErrorUpperBound,
+	})
+	if err != nil || len(key) == 0 {
+		return err
+	}
+	return decodeNumbers(key, &b.Key)
+}
+
+// Time returns the key of a date bucket, such as those of "date_histogram"
+// aggregations, as a UTC time. It returns false if the key is not a number of
+// milliseconds since the epoch.
+func (b *Bucket) Time() (time.Time, bool) {
+	num, ok := b.Key.(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	ms, err := num.Int64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(ms).UTC(), true
+}
+
+// decodeBucket unmarshals the bucket fields listed in known into their
+// destinations, and collects all other fields as sub-aggregation results.
+func decodeBucket(data []byte, known map[string]interface{}) (AggregationResults, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	aggs := make(AggregationResults)
+	for key, val := range fields {
+		dest, ok := known[key]
+		if !ok {
+			aggs[key] = val
+			continue
+		}
+		if err := json.Unmarshal(val, dest); err != nil {
+			return nil, err
+		}
+	}
+
+	return aggs, nil
+}
+
+// SingleBucketResult is the result of single-bucket aggregations such as
+// "filter", "global", "missing", "sampler", "nested", "reverse_nested",
+// "children" and "parent".
+type SingleBucketResult struct {
+	DocCount     int64
+	Aggregations AggregationResults
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (res *SingleBucketResult) UnmarshalJSON(data []byte) (err error) {
+	res.Aggregations, err = decodeBucket(data, map[string]interface{}{
+		"doc_count": &res.DocCount,
+	})
+	return err
+}
+
+// TermsResult is the result of a "terms" aggregation.
+type TermsResult struct {
+	DocCountErrorUpperBound int64    `json:"doc_count_error_upper_bound"`
+	SumOtherDocCount        int64    `json:"sum_other_doc_count"`
+	Buckets                 []Bucket `json:"buckets"`
+}
+
+// HistogramResult is the result of a "histogram" aggregation.
+type HistogramResult struct {
+	Buckets []Bucket `json:"buckets"`
+}
+
+// DateHistogramResult is the result of a "date_histogram" aggregation. The
+// key of each bucket is the bucket's start as milliseconds since the epoch,
+// which Bucket.Time converts.
+type DateHistogramResult struct {
+	Buckets []Bucket `json:"buckets"`
+}
+
+// RangeResult is the result of the "range", "date_range" and "ip_range"
+// aggregations. Buckets are listed in the order of the ranges, whether the
+// aggregation is keyed or not.
+type RangeResult struct {
+	Buckets []RangeBucket
+}
+
+// RangeBucket is a single bucket of a range aggregation result. From and To
+// are numbers for "range" and "date_range" aggregations, and strings for
+// "ip_range" aggregations; they are nil for unbounded sides.
+type RangeBucket struct {
+	Key          string
+	From         interface{}
+	FromAsString string
+	To           interface{}
+	ToAsString   string
+	DocCount     int64
+	Aggregations AggregationResults
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (b *RangeBucket) UnmarshalJSON(data []byte) (err error) {
+	b.Aggregations, err = decodeBucket(data, map[string]interface{}{
+		"key":            &b.Key,
+		"from":           &b.From,
+		"from_as_string": &b.FromAsString,
+		"to":             &b.To,
+		"to_as_string":   &b.ToAsString,
+		"doc_count":      &b.DocCount,
+	})
+	return err
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Keyed results,
+// whose buckets are an object rather than an array, are supported.
+func (res *RangeResult) UnmarshalJSON(data []byte) (err error) {
+	res.Buckets, err = decodeKeyedBuckets(data, func(b *RangeBucket, key string) {
+		b.Key = key
+	})
+	return err
+}
+
+// decodeKeyedBuckets decodes the "buckets" of a multi-bucket aggregation
+// result, which are either an array or, for keyed results, an object. Keyed
+// buckets are returned in order, their key being set via setKey.
+func decodeKeyedBuckets[B any](data []byte, setKey func(b *B, key string)) ([]B, error) {
+	var raw struct {
+		Buckets json.RawMessage `json:"buckets"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	var buckets []B
+	data = bytes.TrimSpace(raw.Buckets)
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if data[0] != '{' {
+		err := json.Unmarshal(data, &buckets)
+		return buckets, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var bucket B
+		if err := dec.Decode(&bucket); err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		setKey(&bucket, key)
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, nil
+}
+
+// Bucket returns the bucket with the provided key.
+func (res *RangeResult) Bucket(key string) (*RangeBucket, bool) {
+	for i := range res.Buckets {
+		if res.Buckets[i].Key == key {
+			return &res.Buckets[i], true
+		}
+	}
+	return nil, false
+}
+
+// CompositeResult is the result of a "composite" aggregation. AfterKey is
+// the key to pass to CompositeAggregation.After to fetch the next page; it is
+// nil once all buckets were returned. Numbers in keys are decoded as
+// json.Number, so that they are sent back to OpenSearch unaltered.
+type CompositeResult struct {
+	AfterKey map[string]interface{}
+	Buckets  []CompositeBucket
+}
+
+// CompositeBucket is a single bucket of a composite aggregation result,
+// whose key holds a value per source of the aggregation.
+type CompositeBucket struct {
+	Key          map[string]interface{}
+	DocCount     int64
+	Aggregations AggregationResults
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (res *CompositeResult) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		AfterKey json.RawMessage   `json:"after_key"`
+		Buckets  []CompositeBucket `json:"buckets"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	res.Buckets = raw.Buckets
+	res.AfterKey = nil
+	if len(raw.AfterKey) > 0 {
+		return decodeNumbers(raw.AfterKey, &res.AfterKey)
+	}
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (b *CompositeBucket) UnmarshalJSON(data []byte) (err error) {
+	var key json.RawMessage
+	b.Aggregations, err = decodeBucket(data, map[string]interface{}{
+		"key":       &key,
+		"doc_count": &b.DocCount,
+	})
+	if err != nil || len(key) == 0 {
+		return err
+	}
+	return decodeNumbers(key, &b.Key)
+}
+
+// decodeNumbers decodes a bucket key, keeping numbers as json.Number.
+func decodeNumbers(data []byte, dest interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(dest)
+}
+
+// FiltersResult is the result of a "filters" aggregation. Buckets of
+// anonymous filters are listed in the order of the filters, followed by the
+// "other" bucket if enabled, and their Key is empty. Buckets of named filters
+// are listed in the order OpenSearch returned them, use Bucket to look them up
+// by key.
+type FiltersResult struct {
+	Buckets []FiltersBucket
+}
+
+// FiltersBucket is a single bucket of a filters aggregation result.
+type FiltersBucket struct {
+	Key          string
+	DocCount     int64
+	Aggregations AggregationResults
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (b *FiltersBucket) UnmarshalJSON(data []byte) (err error) {
+	b.Aggregations, err = decodeBucket(data, map[string]interface{}{
+		"doc_count": &b.DocCount,
+	})
+	return err
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (res *FiltersResult) UnmarshalJSON(data []byte) (err error) {
+	res.Buckets, err = decodeKeyedBuckets(data, func(b *FiltersBucket, key string) {
+		b.Key = key
+	})
+	return err
+}
+
+// Bucket returns the bucket of the filter with the provided key.
+func (res *FiltersResult) Bucket(key string) (*FiltersBucket, bool) {
+	for i := range res.Buckets {
+		if res.Buckets[i].Key == key {
+			return &res.Buckets[i], true
+		}
+	}
+	return nil, false
+}
+
+// SignificantTermsResult is the result of the "significant_terms" and
+// "significant_text" aggregations. DocCount is the size of the result set,
+// and BgCount the size of the background set.
+type SignificantTermsResult struct {
+	DocCount int64               `json:"doc_count"`
+	BgCount  int64               `json:"bg_count"`
+	Buckets  []SignificantBucket `json:"buckets"`
+}
+
+// SignificantBucket is a single bucket of a significant terms result. BgCount
+// is the number of documents of the background set holding the term. Numeric
+// keys are decoded as json.Number.
+type SignificantBucket struct {
+	Key          interface{}
+	KeyAsString  string
+	DocCount     int64
+	BgCount      int64
+	Score        float64
+	Aggregations AggregationResults
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (b *SignificantBucket) UnmarshalJSON(data []byte) (err error) {
+	var key json.RawMessage
+	b.Aggregations, err = decodeBucket(data, map[string]interface{}{
+		"key":           &key,
+		"key_as_string": &b.KeyAsString,
+		"doc_count":     &b.DocCount,
+		"bg_count":      &b.BgCount,
+		"score":         &b.Score,
+	})
+	if err != nil || len(key) == 0 {
+		return err
+	}
+	return decodeNumbers(key, &b.Key)
+}
+
+// GeoGridResult is the result of the "geohash_grid" and "geotile_grid"
+// aggregations. The key of each bucket is the cell's geohash or "zoom/x/y"
+// tile.
+type GeoGridResult struct {
+	Buckets []Bucket `json:"buckets"`
+}
+
+// GeoBoundsResult is the result of a "geo_bounds" aggregation. Bounds is nil
+// if no documents had a value for the aggregated field.
+type GeoBoundsResult struct {
+	Bounds *struct {
+		TopLeft     GeoPoint `json:"top_left"`
+		BottomRight GeoPoint `json:"bottom_right"`
+	} `json:"bounds"`
+}
+
+// GeoCentroidResult is the result of a "geo_centroid" aggregation. Location
+// is nil if no documents had a value for the aggregated field.
+type GeoCentroidResult struct {
+	Location *GeoPoint `json:"location"`
+	Count    int64     `json:"count"`
+}
+
+// BucketMetricValueResult is the result of the "avg_bucket", "sum_bucket",
+// "max_bucket" and "min_bucket" pipeline aggregations. Keys holds the keys of
+// the buckets holding the maximum or minimum value.
+type BucketMetricValueResult struct {
+	Value         *float64 `json:"value"`
+	ValueAsString string   `json:"value_as_string,omitempty"`
+	Keys          []string `json:"keys,omitempty"`
+}
+
+// DerivativeResult is the result of a "derivative" pipeline aggregation.
+// NormalizedValue is set when the aggregation has a unit.
+type DerivativeResult struct {
+	Value           *float64 `json:"value"`
+	ValueAsString   string   `json:"value_as_string,omitempty"`
+	NormalizedValue *float64 `json:"normalized_value,omitempty"`
+}
+
+//----------------------------------------------------------------------------//
+
+// Avg returns the result of an "avg" aggregation.
+func (aggs AggregationResults) Avg(agg *AvgAgg) (*MetricValueResult, error) {
+	return aggs.metricValue(agg)
+}
+
+// WeightedAvg returns the result of a "weighted_avg" aggregation.
+func (aggs AggregationResults) WeightedAvg(agg *WeightedAvgAgg) (*MetricValueResult, error) {
+	return aggs.metricValue(agg)
+}
+
+// Cardinality returns the result of a "cardinality" aggregation.
+func (aggs AggregationResults) Cardinality(agg *CardinalityAgg) (*MetricValueResult, error) {
+	return aggs.metricValue(agg)
+}
+
+// Max returns the result of a "max" aggregation.
+func (aggs AggregationResults) Max(agg *MaxAgg) (*MetricValueResult, error) {
+	return aggs.metricValue(agg)
+}
+
+// Min returns the result of a "min" aggregation.
+func (aggs AggregationResults) Min(agg *MinAgg) (*MetricValueResult, error) {
+	return aggs.metricValue(agg)
+}
+
+// Sum returns the result of a "sum" aggregation.
+func (aggs AggregationResults) Sum(agg *SumAgg) (*MetricValueResult, error) {
+	return aggs.metricValue(agg)
+}
+
+// ValueCount returns the result of a "value_count" aggregation.
+func (aggs AggregationResults) ValueCount(agg *ValueCountAgg) (*MetricValueResult, error) {
+	return aggs.metricValue(agg)
+}
+
+func (aggs AggregationResults) metricValue(agg Aggregation) (*MetricValueResult, error) {
+	return decodeResult[MetricValueResult](aggs, agg)
+}
+
+// Percentiles returns the result of a "percentiles" aggregation.
+func (aggs AggregationResults) Percentiles(agg *PercentilesAgg) (*PercentilesResult, error) {
+	return decodeResult[PercentilesResult](aggs, agg)
+}
+
+// Stats returns the result of a "stats" aggregation.
+func (aggs AggregationResults) Stats(agg *StatsAgg) (*StatsResult, error) {
+	return decodeResult[StatsResult](aggs, agg)
+}
+
+// StringStats returns the result of a "string_stats" aggregation.
+func (aggs AggregationResults) StringStats(agg *StringStatsAgg) (*StringStatsResult, error) {
+	return decodeResult[StringStatsResult](aggs, agg)
+}
+
+// TopHits returns the result of a "top_hits" aggregation.
+func (aggs AggregationResults) TopHits(agg *TopHitsAgg) (*TopHitsResult, error) {
+	return decodeResult[TopHitsResult](aggs, agg)
+}
+
+// Terms returns the result of a "terms" aggregation.
+func (aggs AggregationResults) Terms(agg *TermsAggregation) (*TermsResult, error) {
+	return decodeResult[TermsResult](aggs, agg)
+}
+
+// Histogram returns the result of a "histogram" aggregation.
+func (aggs AggregationResults) Histogram(agg *HistogramAggregation) (*HistogramResult, error) {
+	return decodeResult[HistogramResult](aggs, agg)
+}
+
+// DateHistogram returns the result of a "date_histogram" aggregation.
+func (aggs AggregationResults) DateHistogram(agg *DateHistogramAggregation) (*DateHistogramResult, error) {
+	return decodeResult[DateHistogramResult](aggs, agg)
+}
+
+// Range returns the result of a "range" aggregation.
+func (aggs AggregationResults) Range(agg *RangeAggregation) (*RangeResult, error) {
+	return aggs.ranges(agg)
+}
+
+// DateRange returns the result of a "date_range" aggregation.
+func (aggs AggregationResults) DateRange(agg *DateRangeAggregation) (*RangeResult, error) {
+	return aggs.ranges(agg)
+}
+
+// IPRange returns the result of an "ip_range" aggregation.
+func (aggs AggregationResults) IPRange(agg *IPRangeAggregation) (*RangeResult, error) {
+	return aggs.ranges(agg)
+}
+
+func (aggs AggregationResults) ranges(agg Aggregation) (*RangeResult, error) {
+	return decodeResult[RangeResult](aggs, agg)
+}
+
+// Composite returns the result of a "composite" aggregation.
+func (aggs AggregationResults) Composite(agg *CompositeAggregation) (*CompositeResult, error) {
+	return decodeResult[CompositeResult](aggs, agg)
+}
+
+// GeoGrid returns the result of a "geohash_grid" or "geotile_grid"
+// aggregation.
+func (aggs AggregationResults) GeoGrid(agg *GeoGridAggregation) (*GeoGridResult, error) {
+	return decodeResult[GeoGridResult](aggs, agg)
+}
+
+// GeoBounds returns the result of a "geo_bounds" aggregation.
+func (aggs AggregationResults) GeoBounds(agg *GeoBoundsAggregation) (*GeoBoundsResult, error) {
+	return decodeResult[GeoBoundsResult](aggs, agg)
+}
+
+// GeoCentroid returns the result of a "geo_centroid" aggregation.
+func (aggs AggregationResults) GeoCentroid(agg *GeoCentroidAggregation) (*GeoCentroidResult, error) {
+	return decodeResult[GeoCentroidResult](aggs, agg)
+}
+
+// BucketMetric returns the result of an "avg_bucket", "sum_bucket",
+// "max_bucket" or "min_bucket" aggregation.
+func (aggs AggregationResults) BucketMetric(agg *BucketMetricAgg) (*BucketMetricValueResult, error) {
+	return decodeResult[BucketMetricValueResult](aggs, agg)
+}
+
+// StatsBucket returns the result of a "stats_bucket" aggregation.
+func (aggs AggregationResults) StatsBucket(agg *BucketMetricAgg) (*StatsResult, error) {
+	return decodeResult[StatsResult](aggs, agg)
+}
+
+// Derivative returns the result of a "derivative" aggregation.
+func (aggs AggregationResults) Derivative(agg *DerivativeAggregation) (*DerivativeResult, error) {
+	return decodeResult[DerivativeResult](aggs, agg)
+}
+
+// CumulativeSum returns the result of a "cumulative_sum" aggregation.
+func (aggs AggregationResults) CumulativeSum(agg *CumulativeSumAggregation) (*MetricValueResult, error) {
+	return aggs.metricValue(agg)
+}
+
+// MovingFn returns the result of a "moving_fn" aggregation.
+func (aggs AggregationResults) MovingFn(agg *MovingFnAggregation) (*MetricValueResult, error) {
+	return aggs.metricValue(agg)
+}
+
+// SerialDiff returns the result of a "serial_diff" aggregation.
+func (aggs AggregationResults) SerialDiff(agg *SerialDiffAggregation) (*MetricValueResult, error) {
+	return aggs.metricValue(agg)
+}
+
+// BucketScript returns the result of a "bucket_script" aggregation.
+func (aggs AggregationResults) BucketScript(agg *BucketScriptAggregation) (*MetricValueResult, error) {
+	return aggs.metricValue(agg)
+}
+
+// Filters returns the result of a "filters" aggregation.
+func (aggs AggregationResults) Filters(agg *FiltersAggregation) (*FiltersResult, error) {
+	return decodeResult[FiltersResult](aggs, agg)
+}
+
+// Global returns the result of a "global" aggregation.
+func (aggs AggregationResults) Global(agg *GlobalAggregation) (*SingleBucketResult, error) {
+	return aggs.singleBucket(agg)
+}
+
+// Missing returns the result of a "missing" aggregation.
+func (aggs AggregationResults) Missing(agg *MissingAggregation) (*SingleBucketResult, error) {
+	return aggs.singleBucket(agg)
+}
+
+// Sampler returns the result of a "sampler" aggregation.
+func (aggs AggregationResults) Sampler(agg *SamplerAggregation) (*SingleBucketResult, error) {
+	return aggs.singleBucket(agg)
+}
+
+// DiversifiedSampler returns the result of a "diversified_sampler"
+// aggregation.
+func (aggs AggregationResults) DiversifiedSampler(agg *DiversifiedSamplerAggregation) (*SingleBucketResult, error) {
+	return aggs.singleBucket(agg)
+}
+
+// SignificantTerms returns the result of a "significant_terms" aggregation.
+func (aggs AggregationResults) SignificantTerms(agg *SignificantTermsAggregation) (*SignificantTermsResult, error) {
+	return aggs.significant(agg)
+}
+
+// SignificantText returns the result of a "significant_text" aggregation.
+func (aggs AggregationResults) SignificantText(agg *SignificantTextAggregation) (*SignificantTermsResult, error) {
+	return aggs.significant(agg)
+}
+
+func (aggs AggregationResults) significant(agg Aggregation) (*SignificantTermsResult, error) {
+	return decodeResult[SignificantTermsResult](aggs, agg)
+}
+
+// Filter returns the result of a "filter" aggregation.
+func (aggs AggregationResults) Filter(agg *FilterAggregation) (*SingleBucketResult, error) {
+	return aggs.singleBucket(agg)
+}
+
+// Nested returns the result of a "nested" aggregation.
+func (aggs AggregationResults) Nested(agg *NestedAggregation) (*SingleBucketResult, error) {
+	return aggs.singleBucket(agg)
+}
+
+// ReverseNested returns the result of a "reverse_nested" aggregation.
+func (aggs AggregationResults) ReverseNested(agg *ReverseNestedAggregation) (*SingleBucketResult, error) {
+	return aggs.singleBucket(agg)
+}
+
+// Join returns the result of a "children" or "parent" aggregation.
+func (aggs AggregationResults) Join(agg *JoinAggregation) (*SingleBucketResult, error) {
+	return aggs.singleBucket(agg)
+}
+
+func (aggs AggregationResults) singleBucket(agg Aggregation) (*SingleBucketResult, error) {
+	return decodeResult[SingleBucketResult](aggs, agg)
+}
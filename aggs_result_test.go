@@ -0,0 +1,348 @@
+package osquery
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jgroeneveld/trial/assert"
+)
+
+func TestAggregationResults(t *testing.T) {
+	avgScore := Avg("avg_score", "score")
+	maxScore := Max("max_score", "score")
+	percentiles := Percentiles("load_time", "load").Percents(50, 99)
+	stats := Stats("score_stats", "score")
+	byTag := TermsAgg("by_tag", "tag").Aggs(avgScore)
+	comments := NestedAgg("comments", "comments").Aggs(
+		TermsAgg("by_author", "comments.author"),
+	)
+	missing := Sum("missing", "score")
+
+	raw := json.RawMessage(`{
+		"max_score": {"value": 9.5},
+		"load_time": {"values": {"50.0": 120.5, "99.0": 830.0}},
+		"score_stats": {"count": 3, "min": 1, "max": 9.5, "avg": 5, "sum": 15},
+		"by_tag": {
+			"doc_count_error_upper_bound": 0,
+			"sum_other_doc_count": 4,
+			"buckets": [
+				{"key": "go", "doc_count": 3, "avg_score": {"value": 4.5}},
+				{"key": "rust", "doc_count": 1, "avg_score": {"value": null}}
+			]
+		},
+		"comments": {
+			"doc_count": 7,
+			"by_author": {
+				"doc_count_error_upper_bound": 0,
+				"sum_other_doc_count": 0,
+				"buckets": [{"key": "kimchy", "doc_count": 7}]
+			}
+		}
+	}`)
+
+	aggs, err := ParseAggregations(raw)
+	assert.Nil(t, err)
+
+	t.Run("metric value", func(t *testing.T) {
+		res, err := aggs.Max(maxScore)
+		assert.Nil(t, err)
+		assert.Equal(t, 9.5, *res.Value)
+	})
+
+	t.Run("missing result", func(t *testing.T) {
+		_, err := aggs.Sum(missing)
+		assert.True(t, errors.Is(err, ErrAggregationNotFound))
+	})
+
+	t.Run("percentiles", func(t *testing.T) {
+		res, err := aggs.Percentiles(percentiles)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(res.Values))
+		assert.Equal(t, 50.0, res.Values[0].Percent)
+		val, ok := res.Percentile(99)
+		assert.True(t, ok)
+		assert.Equal(t, 830.0, val)
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		res, err := aggs.Stats(stats)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), res.Count)
+		assert.Equal(t, 15.0, res.Sum)
+	})
+
+	t.Run("terms with sub-aggregations", func(t *testing.T) {
+		res, err := aggs.Terms(byTag)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(4), res.SumOtherDocCount)
+		assert.Equal(t, 2, len(res.Buckets))
+		assert.Equal(t, "go", res.Buckets[0].Key)
+		assert.Equal(t, int64(3), res.Buckets[0].DocCount)
+
+		avg, err := res.Buckets[0].Aggregations.Avg(avgScore)
+		assert.Nil(t, err)
+		assert.Equal(t, 4.5, *avg.Value)
+
+		avg, err = res.Buckets[1].Aggregations.Avg(avgScore)
+		assert.Nil(t, err)
+		assert.True(t, avg.Value == nil)
+	})
+
+	t.Run("single bucket with sub-aggregations", func(t *testing.T) {
+		res, err := aggs.Nested(comments)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(7), res.DocCount)
+
+		authors, err := res.Aggregations.Terms(TermsAgg("by_author", "comments.author"))
+		assert.Nil(t, err)
+		assert.Equal(t, "kimchy", authors.Buckets[0].Key)
+	})
+}
+
+func TestAggregationResultsMalformed(t *testing.T) {
+	byTag := TermsAgg("by_tag", "tag")
+
+	aggs, err := ParseAggregations(json.RawMessage(`{
+		"by_tag": {"buckets": [{"key": "go", "doc_count": "three"}]}
+	}`))
+	assert.Nil(t, err)
+
+	res, err := aggs.Terms(byTag)
+	assert.True(t, res == nil)
+	assert.NotNil(t, err)
+	assert.False(t, errors.Is(err, ErrAggregationNotFound))
+}
+
+func TestBucketNumericKey(t *testing.T) {
+	byUser := TermsAgg("by_user", "user_id")
+
+	aggs, err := ParseAggregations(json.RawMessage(`{
+		"by_user": {"buckets": [{"key": 1700000000000123456, "doc_count": 1}]}
+	}`))
+	assert.Nil(t, err)
+
+	res, err := aggs.Terms(byUser)
+	assert.Nil(t, err)
+	assert.Equal(t, json.Number("1700000000000123456"), res.Buckets[0].Key)
+}
+
+func TestPercentilesResultNonKeyed(t *testing.T) {
+	var res PercentilesResult
+	err := json.Unmarshal(
+		[]byte(`{"values": [{"key": 95.0, "value": 60}, {"key": 99.0, "value": null}]}`),
+		&res,
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(res.Values))
+
+	val, ok := res.Percentile(95)
+	assert.True(t, ok)
+	assert.Equal(t, 60.0, val)
+
+	_, ok = res.Percentile(99)
+	assert.False(t, ok)
+}
+
+func TestPercentilesResultKeyedFormatted(t *testing.T) {
+	loadTime := Percentiles("load_time", "load").Percents(50, 99)
+
+	aggs, err := ParseAggregations(json.RawMessage(`{"load_time": {"values": {
+		"50.0": 1.5,
+		"50.0_as_string": "1.5ms",
+		"99.0": null,
+		"99.0_as_string": null
+	}}}`))
+	assert.Nil(t, err)
+
+	res, err := aggs.Percentiles(loadTime)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(res.Values))
+	assert.Equal(t, 50.0, res.Values[0].Percent)
+	assert.Equal(t, 1.5, *res.Values[0].Value)
+	assert.Equal(t, "1.5ms", res.Values[0].ValueAsString)
+	assert.True(t, res.Values[1].Value == nil)
+	assert.Equal(t, "", res.Values[1].ValueAsString)
+}
+
+func TestDateHistogramResult(t *testing.T) {
+	perDay := DateHistogramAgg("per_day", "published_at").
+		CalendarInterval("day").
+		Aggs(Sum("views", "views"))
+
+	aggs, err := ParseAggregations(json.RawMessage(`{
+		"per_day": {
+			"buckets": [
+				{"key_as_string": "2024-03-01", "key": 1709251200000, "doc_count": 2, "views": {"value": 30}},
+				{"key_as_string": "2024-03-02", "key": 1709337600000, "doc_count": 0, "views": {"value": 0}}
+			]
+		}
+	}`))
+	assert.Nil(t, err)
+
+	res, err := aggs.DateHistogram(perDay)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(res.Buckets))
+	assert.Equal(t, "2024-03-01", res.Buckets[0].KeyAsString)
+
+	day, ok := res.Buckets[1].Time()
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), day)
+
+	views, err := res.Buckets[0].Aggregations.Sum(Sum("views", "views"))
+	assert.Nil(t, err)
+	assert.Equal(t, 30.0, *views.Value)
+}
+
+func TestRangeResult(t *testing.T) {
+	bands := RangeAgg("bands", "price").AddRange(nil, 100).AddRange(100, nil)
+	keyed := RangeAgg("keyed", "price").
+		AddKeyedRange("cheap", nil, 100).
+		AddKeyedRange("expensive", 100, nil).
+		Keyed(true)
+	networks := IPRangeAgg("networks", "ip").AddMask("10.0.0.0/25")
+
+	aggs, err := ParseAggregations(json.RawMessage(`{
+		"bands": {"buckets": [
+			{"key": "*-100.0", "to": 100.0, "doc_count": 2},
+			{"key": "100.0-*", "from": 100.0, "doc_count": 1, "avg_rating": {"value": 4}}
+		]},
+		"keyed": {"buckets": {
+			"expensive": {"from": 100.0, "doc_count": 1},
+			"cheap": {"to": 100.0, "doc_count": 2}
+		}},
+		"networks": {"buckets": [
+			{"key": "10.0.0.0/25", "from": "10.0.0.0", "to": "10.0.0.128", "doc_count": 5}
+		]}
+	}`))
+	assert.Nil(t, err)
+
+	res, err := aggs.Range(bands)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(res.Buckets))
+	assert.True(t, res.Buckets[0].From == nil)
+	assert.Equal(t, 100.0, res.Buckets[0].To)
+	avg, err := res.Buckets[1].Aggregations.Avg(Avg("avg_rating", "rating"))
+	assert.Nil(t, err)
+	assert.Equal(t, 4.0, *avg.Value)
+
+	res, err = aggs.Range(keyed)
+	assert.Nil(t, err)
+	assert.Equal(t, "expensive", res.Buckets[0].Key)
+	cheap, ok := res.Bucket("cheap")
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), cheap.DocCount)
+
+	res, err = aggs.IPRange(networks)
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.0.128", res.Buckets[0].To)
+}
+
+func TestFiltersResult(t *testing.T) {
+	named := FiltersAgg("named").
+		Filter("errors", Term("level", "error")).
+		Filter("warnings", Term("level", "warning")).
+		OtherBucket(true)
+	anonymous := FiltersAgg("anonymous").AnonymousFilters(Term("level", "error"))
+	all := GlobalAgg("all")
+
+	aggs, err := ParseAggregations(json.RawMessage(`{
+		"named": {"buckets": {
+			"errors": {"doc_count": 3, "avg_size": {"value": 10}},
+			"warnings": {"doc_count": 1},
+			"_other_": {"doc_count": 5}
+		}},
+		"anonymous": {"buckets": [{"doc_count": 3}]},
+		"all": {"doc_count": 9}
+	}`))
+	assert.Nil(t, err)
+
+	res, err := aggs.Filters(named)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(res.Buckets))
+	assert.Equal(t, "errors", res.Buckets[0].Key)
+	other, ok := res.Bucket("_other_")
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), other.DocCount)
+	size, err := res.Buckets[0].Aggregations.Avg(Avg("avg_size", "size"))
+	assert.Nil(t, err)
+	assert.Equal(t, 10.0, *size.Value)
+
+	res, err = aggs.Filters(anonymous)
+	assert.Nil(t, err)
+	assert.Equal(t, "", res.Buckets[0].Key)
+	assert.Equal(t, int64(3), res.Buckets[0].DocCount)
+
+	global, err := aggs.Global(all)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(9), global.DocCount)
+}
+
+func TestSignificantTermsResult(t *testing.T) {
+	unusual := SignificantTermsAgg("unusual", "crime_type").Heuristic(ChiSquareHeuristic())
+
+	aggs, err := ParseAggregations(json.RawMessage(`{"unusual": {
+		"doc_count": 47347,
+		"bg_count": 5064554,
+		"buckets": [
+			{"key": "Bicycle theft", "doc_count": 3640, "score": 0.371, "bg_count": 66799}
+		]
+	}}`))
+	assert.Nil(t, err)
+
+	res, err := aggs.SignificantTerms(unusual)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5064554), res.BgCount)
+	assert.Equal(t, "Bicycle theft", res.Buckets[0].Key)
+	assert.Equal(t, int64(66799), res.Buckets[0].BgCount)
+	assert.Equal(t, 0.371, res.Buckets[0].Score)
+}
+
+func TestGeoResults(t *testing.T) {
+	center := GeoCentroidAgg("center", "location")
+	cells := GeoHashGridAgg("cells", "location").Aggs(center)
+	viewport := GeoBoundsAgg("viewport", "location")
+
+	aggs, err := ParseAggregations(json.RawMessage(`{
+		"cells": {"buckets": [
+			{"key": "u17", "doc_count": 3, "center": {"location": {"lat": 52.37, "lon": 4.89}, "count": 3}}
+		]},
+		"viewport": {"bounds": {
+			"top_left": {"lat": 52.37, "lon": 4.89},
+			"bottom_right": {"lat": 48.86, "lon": 2.35}
+		}}
+	}`))
+	assert.Nil(t, err)
+
+	grid, err := aggs.GeoGrid(cells)
+	assert.Nil(t, err)
+	assert.Equal(t, "u17", grid.Buckets[0].Key)
+
+	centroid, err := grid.Buckets[0].Aggregations.GeoCentroid(center)
+	assert.Nil(t, err)
+	assert.Equal(t, LatLon(52.37, 4.89), *centroid.Location)
+	assert.Equal(t, int64(3), centroid.Count)
+
+	bounds, err := aggs.GeoBounds(viewport)
+	assert.Nil(t, err)
+	assert.Equal(t, LatLon(48.86, 2.35), bounds.Bounds.BottomRight)
+}
+
+func TestJoinResult(t *testing.T) {
+	quantity := Sum("quantity", "quantity")
+	items := ChildrenAgg("items", "line_item").Aggs(quantity)
+
+	aggs, err := ParseAggregations(json.RawMessage(`{
+		"items": {"doc_count": 12, "quantity": {"value": 40}}
+	}`))
+	assert.Nil(t, err)
+
+	res, err := aggs.Join(items)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(12), res.DocCount)
+
+	sum, err := res.Aggregations.Sum(quantity)
+	assert.Nil(t, err)
+	assert.Equal(t, 40.0, *sum.Value)
+}
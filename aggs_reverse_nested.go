@@ -30,6 +30,10 @@ func (agg *ReverseNestedAggregation) Aggs(aggs ...Aggregation) *ReverseNestedAgg
 	return agg
 }
 
+func (agg *ReverseNestedAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
 // Map builds the OpenSearch aggregation map.
 func (agg *ReverseNestedAggregation) Map() map[string]interface{} {
 	reverseNestedBody := make(map[string]interface{})
@@ -0,0 +1,166 @@
+package osquery
+
+// SamplerAggregation represents an aggregation of type "sampler", as
+// described in https://opensearch.org/docs/latest/aggregations/bucket/sampler/
+// It limits its sub-aggregations to the top-scoring documents of each shard.
+type SamplerAggregation struct {
+	name      string
+	shardSize *uint64
+	aggs      []Aggregation
+}
+
+// SamplerAgg creates a new aggregation of type "sampler".
+func SamplerAgg(name string) *SamplerAggregation {
+	return &SamplerAggregation{
+		name: name,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *SamplerAggregation) Name() string {
+	return agg.name
+}
+
+// ShardSize sets the number of top-scoring documents sampled on each shard.
+// The default is 100.
+func (agg *SamplerAggregation) ShardSize(size uint64) *SamplerAggregation {
+	agg.shardSize = &size
+	return agg
+}
+
+// Aggs sets sub-aggregations for the aggregation.
+func (agg *SamplerAggregation) Aggs(aggs ...Aggregation) *SamplerAggregation {
+	agg.aggs = aggs
+	return agg
+}
+
+func (agg *SamplerAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *SamplerAggregation) Map() map[string]interface{} {
+	innerMap := make(map[string]interface{})
+	if agg.shardSize != nil {
+		innerMap["shard_size"] = *agg.shardSize
+	}
+
+	outerMap := map[string]interface{}{
+		"sampler": innerMap,
+	}
+
+	if len(agg.aggs) > 0 {
+		subAggs := make(map[string]map[string]interface{})
+		for _, sub := range agg.aggs {
+			subAggs[sub.Name()] = sub.Map()
+		}
+		outerMap["aggs"] = subAggs
+	}
+
+	return outerMap
+}
+
+//----------------------------------------------------------------------------//
+
+// DiversifiedSamplerAggregation represents an aggregation of type
+// "diversified_sampler", as described in
+// https://opensearch.org/docs/latest/aggregations/bucket/diversified-sampler/
+// It is like a sampler aggregation, except that the number of sampled
+// documents sharing the same value is limited.
+type DiversifiedSamplerAggregation struct {
+	name            string
+	field           string
+	script          *ScriptField
+	shardSize       *uint64
+	maxDocsPerValue *uint64
+	executionHint   string
+	aggs            []Aggregation
+}
+
+// DiversifiedSamplerAgg creates a new aggregation of type
+// "diversified_sampler", diversifying the sample on the provided field.
+func DiversifiedSamplerAgg(name, field string) *DiversifiedSamplerAggregation {
+	return &DiversifiedSamplerAggregation{
+		name:  name,
+		field: field,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *DiversifiedSamplerAggregation) Name() string {
+	return agg.name
+}
+
+// Script sets a script generating the values to diversify on, in which case
+// the field may be empty.
+func (agg *DiversifiedSamplerAggregation) Script(script *ScriptField) *DiversifiedSamplerAggregation {
+	agg.script = script
+	return agg
+}
+
+// ShardSize sets the number of top-scoring documents sampled on each shard.
+// The default is 100.
+func (agg *DiversifiedSamplerAggregation) ShardSize(size uint64) *DiversifiedSamplerAggregation {
+	agg.shardSize = &size
+	return agg
+}
+
+// MaxDocsPerValue sets the maximum number of sampled documents sharing the
+// same value. The default is 1.
+func (agg *DiversifiedSamplerAggregation) MaxDocsPerValue(max uint64) *DiversifiedSamplerAggregation {
+	agg.maxDocsPerValue = &max
+	return agg
+}
+
+// ExecutionHint sets the mechanism used to deduplicate values: "map",
+// "global_ordinals" or "bytes_hash".
+func (agg *DiversifiedSamplerAggregation) ExecutionHint(hint string) *DiversifiedSamplerAggregation {
+	agg.executionHint = hint
+	return agg
+}
+
+// Aggs sets sub-aggregations for the aggregation.
+func (agg *DiversifiedSamplerAggregation) Aggs(aggs ...Aggregation) *DiversifiedSamplerAggregation {
+	agg.aggs = aggs
+	return agg
+}
+
+func (agg *DiversifiedSamplerAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *DiversifiedSamplerAggregation) Map() map[string]interface{} {
+	innerMap := make(map[string]interface{})
+	if agg.field != "" {
+		innerMap["field"] = agg.field
+	}
+	if agg.script != nil {
+		innerMap["script"] = agg.script.Map()["script"]
+	}
+	if agg.shardSize != nil {
+		innerMap["shard_size"] = *agg.shardSize
+	}
+	if agg.maxDocsPerValue != nil {
+		innerMap["max_docs_per_value"] = *agg.maxDocsPerValue
+	}
+	if agg.executionHint != "" {
+		innerMap["execution_hint"] = agg.executionHint
+	}
+
+	outerMap := map[string]interface{}{
+		"diversified_sampler": innerMap,
+	}
+
+	if len(agg.aggs) > 0 {
+		subAggs := make(map[string]map[string]interface{})
+		for _, sub := range agg.aggs {
+			subAggs[sub.Name()] = sub.Map()
+		}
+		outerMap["aggs"] = subAggs
+	}
+
+	return outerMap
+}
@@ -0,0 +1,53 @@
+package osquery
+
+import "testing"
+
+func TestSamplerAggs(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"sampler agg",
+			SamplerAgg("sample").
+				ShardSize(200).
+				Aggs(TermsAgg("keywords", "tags")),
+			map[string]interface{}{
+				"sampler": map[string]interface{}{
+					"shard_size": 200,
+				},
+				"aggs": map[string]interface{}{
+					"keywords": map[string]interface{}{
+						"terms": map[string]interface{}{
+							"field": "tags",
+						},
+					},
+				},
+			},
+		},
+		{
+			"diversified sampler agg",
+			DiversifiedSamplerAgg("sample", "author").
+				ShardSize(200).
+				MaxDocsPerValue(3).
+				ExecutionHint("map"),
+			map[string]interface{}{
+				"diversified_sampler": map[string]interface{}{
+					"field":              "author",
+					"shard_size":         200,
+					"max_docs_per_value": 3,
+					"execution_hint":     "map",
+				},
+			},
+		},
+		{
+			"diversified sampler agg with script",
+			DiversifiedSamplerAgg("sample", "").
+				Script(Script("").Source("doc['author'].value")),
+			map[string]interface{}{
+				"diversified_sampler": map[string]interface{}{
+					"script": map[string]interface{}{
+						"source": "doc['author'].value",
+					},
+				},
+			},
+		},
+	})
+}
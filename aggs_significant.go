@@ -0,0 +1,373 @@
+package osquery
+
+// SignificanceHeuristic represents the heuristic scoring the terms of
+// significant terms and significant text aggregations, as described in
+// https://opensearch.org/docs/latest/aggregations/bucket/significant-terms/
+// All heuristics share the same structure, but they don't necessarily support
+// all the same options. The library does not attempt to verify provided
+// options are supported.
+type SignificanceHeuristic struct {
+	name                 string
+	includeNegatives     *bool
+	backgroundIsSuperset *bool
+	script               *ScriptField
+}
+
+// JLHHeuristic creates a "jlh" heuristic, the default one.
+func JLHHeuristic() *SignificanceHeuristic {
+	return &SignificanceHeuristic{name: "jlh"}
+}
+
+// MutualInformationHeuristic creates a "mutual_information" heuristic. It
+// supports IncludeNegatives and BackgroundIsSuperset.
+func MutualInformationHeuristic() *SignificanceHeuristic {
+	return &SignificanceHeuristic{name: "mutual_information"}
+}
+
+// ChiSquareHeuristic creates a "chi_square" heuristic. It supports
+// IncludeNegatives and BackgroundIsSuperset.
+func ChiSquareHeuristic() *SignificanceHeuristic {
+	return &SignificanceHeuristic{name: "chi_square"}
+}
+
+// GNDHeuristic creates a "gnd" (Google normalized distance) heuristic. It
+// supports BackgroundIsSuperset.
+func GNDHeuristic() *SignificanceHeuristic {
+	return &SignificanceHeuristic{name: "gnd"}
+}
+
+// PercentageHeuristic creates a "percentage" heuristic.
+func PercentageHeuristic() *SignificanceHeuristic {
+	return &SignificanceHeuristic{name: "percentage"}
+}
+
+// ScriptHeuristic creates a "script_heuristic" heuristic, scoring terms with
+// the provided script. The script can access the "_subset_freq",
+// "_superset_freq", "_subset_size" and "_superset_size" parameters.
+func ScriptHeuristic(script *ScriptField) *SignificanceHeuristic {
+	return &SignificanceHeuristic{name: "script_heuristic", script: script}
+}
+
+// IncludeNegatives sets whether terms that appear less often in the result
+// set than in the background are included.
+func (h *SignificanceHeuristic) IncludeNegatives(b bool) *SignificanceHeuristic {
+	h.includeNegatives = &b
+	return h
+}
+
+// BackgroundIsSuperset sets whether the background set contains the result
+// set. It should be false when using a background filter that doesn't.
+func (h *SignificanceHeuristic) BackgroundIsSuperset(b bool) *SignificanceHeuristic {
+	h.backgroundIsSuperset = &b
+	return h
+}
+
+// Map returns a map representation of the heuristic, thus implementing the
+// Mappable interface.
+func (h *SignificanceHeuristic) Map() map[string]interface{} {
+	params := make(map[string]interface{})
+	if h.includeNegatives != nil {
+		params["include_negatives"] = *h.includeNegatives
+	}
+	if h.backgroundIsSuperset != nil {
+		params["background_is_superset"] = *h.backgroundIsSuperset
+	}
+	if h.script != nil {
+		params["script"] = h.script.Map()["script"]
+	}
+
+	return map[string]interface{}{
+		h.name: params,
+	}
+}
+
+// significantMap adds the options shared by the significant terms and
+// significant text aggregations to innerMap, and builds the aggregation's
+// map.
+func significantMap(
+	aggType string,
+	innerMap map[string]interface{},
+	backgroundFilter Mappable,
+	heuristic *SignificanceHeuristic,
+	aggs []Aggregation,
+) map[string]interface{} {
+	if backgroundFilter != nil {
+		innerMap["background_filter"] = backgroundFilter.Map()
+	}
+	if heuristic != nil {
+		for key, val := range heuristic.Map() {
+			innerMap[key] = val
+		}
+	}
+
+	outerMap := map[string]interface{}{
+		aggType: innerMap,
+	}
+	if len(aggs) > 0 {
+		subAggs := make(map[string]map[string]interface{})
+		for _, sub := range aggs {
+			subAggs[sub.Name()] = sub.Map()
+		}
+		outerMap["aggs"] = subAggs
+	}
+
+	return outerMap
+}
+
+//----------------------------------------------------------------------------//
+
+// SignificantTermsAggregation represents an aggregation of type
+// "significant_terms", as described in
+// https://opensearch.org/docs/latest/aggregations/bucket/significant-terms/
+type SignificantTermsAggregation struct {
+	name             string
+	field            string
+	size             *uint64
+	shardSize        *uint64
+	minDocCount      *uint64
+	shardMinDocCount *uint64
+	backgroundFilter Mappable
+	heuristic        *SignificanceHeuristic
+	include          interface{}
+	exclude          interface{}
+	executionHint    TermsExecutionHint
+	aggs             []Aggregation
+}
+
+// SignificantTermsAgg creates a new aggregation of type "significant_terms".
+func SignificantTermsAgg(name, field string) *SignificantTermsAggregation {
+	return &SignificantTermsAggregation{
+		name:  name,
+		field: field,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *SignificantTermsAggregation) Name() string {
+	return agg.name
+}
+
+// Size sets the number of term buckets to return.
+func (agg *SignificantTermsAggregation) Size(size uint64) *SignificantTermsAggregation {
+	agg.size = &size
+	return agg
+}
+
+// ShardSize sets how many terms to request from each shard.
+func (agg *SignificantTermsAggregation) ShardSize(size uint64) *SignificantTermsAggregation {
+	agg.shardSize = &size
+	return agg
+}
+
+// MinDocCount sets the minimum number of documents a term must match to be
+// returned. The default is 3.
+func (agg *SignificantTermsAggregation) MinDocCount(min uint64) *SignificantTermsAggregation {
+	agg.minDocCount = &min
+	return agg
+}
+
+// ShardMinDocCount sets the minimum number of documents a term must match on
+// a shard to be returned by that shard.
+func (agg *SignificantTermsAggregation) ShardMinDocCount(min uint64) *SignificantTermsAggregation {
+	agg.shardMinDocCount = &min
+	return agg
+}
+
+// BackgroundFilter sets the query defining the background set terms are
+// compared to. The default background is the whole index.
+func (agg *SignificantTermsAggregation) BackgroundFilter(filter Mappable) *SignificantTermsAggregation {
+	agg.backgroundFilter = filter
+	return agg
+}
+
+// Heuristic sets the heuristic used to score terms.
+func (agg *SignificantTermsAggregation) Heuristic(heuristic *SignificanceHeuristic) *SignificantTermsAggregation {
+	agg.heuristic = heuristic
+	return agg
+}
+
+// Include filters the values for buckets. A single value is interpreted as a
+// regular expression, multiple values as a list of exact terms.
+func (agg *SignificantTermsAggregation) Include(include ...string) *SignificantTermsAggregation {
+	agg.include = termsFilter(include)
+	return agg
+}
+
+// Exclude filters out values from the buckets. A single value is interpreted
+// as a regular expression, multiple values as a list of exact terms.
+func (agg *SignificantTermsAggregation) Exclude(exclude ...string) *SignificantTermsAggregation {
+	agg.exclude = termsFilter(exclude)
+	return agg
+}
+
+// ExecutionHint sets the mechanism used to execute the aggregation.
+func (agg *SignificantTermsAggregation) ExecutionHint(hint TermsExecutionHint) *SignificantTermsAggregation {
+	agg.executionHint = hint
+	return agg
+}
+
+// Aggs sets sub-aggregations for the aggregation.
+func (agg *SignificantTermsAggregation) Aggs(aggs ...Aggregation) *SignificantTermsAggregation {
+	agg.aggs = aggs
+	return agg
+}
+
+func (agg *SignificantTermsAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *SignificantTermsAggregation) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"field": agg.field,
+	}
+	if agg.size != nil {
+		innerMap["size"] = *agg.size
+	}
+	if agg.shardSize != nil {
+		innerMap["shard_size"] = *agg.shardSize
+	}
+	if agg.minDocCount != nil {
+		innerMap["min_doc_count"] = *agg.minDocCount
+	}
+	if agg.shardMinDocCount != nil {
+		innerMap["shard_min_doc_count"] = *agg.shardMinDocCount
+	}
+	if agg.include != nil {
+		innerMap["include"] = agg.include
+	}
+	if agg.exclude != nil {
+		innerMap["exclude"] = agg.exclude
+	}
+	if agg.executionHint != "" {
+		innerMap["execution_hint"] = agg.executionHint
+	}
+
+	return significantMap("significant_terms", innerMap, agg.backgroundFilter, agg.heuristic, agg.aggs)
+}
+
+//----------------------------------------------------------------------------//
+
+// SignificantTextAggregation represents an aggregation of type
+// "significant_text", as described in
+// https://opensearch.org/docs/latest/aggregations/bucket/significant-text/
+// It is like a significant terms aggregation, but works on text fields by
+// re-analyzing the source of the documents.
+type SignificantTextAggregation struct {
+	name                string
+	field               string
+	size                *uint64
+	shardSize           *uint64
+	minDocCount         *uint64
+	shardMinDocCount    *uint64
+	backgroundFilter    Mappable
+	heuristic           *SignificanceHeuristic
+	filterDuplicateText *bool
+	sourceFields        []string
+	aggs                []Aggregation
+}
+
+// SignificantTextAgg creates a new aggregation of type "significant_text".
+func SignificantTextAgg(name, field string) *SignificantTextAggregation {
+	return &SignificantTextAggregation{
+		name:  name,
+		field: field,
+	}
+}
+
+// Name returns the name of the aggregation.
+func (agg *SignificantTextAggregation) Name() string {
+	return agg.name
+}
+
+// Size sets the number of term buckets to return.
+func (agg *SignificantTextAggregation) Size(size uint64) *SignificantTextAggregation {
+	agg.size = &size
+	return agg
+}
+
+// ShardSize sets how many terms to request from each shard.
+func (agg *SignificantTextAggregation) ShardSize(size uint64) *SignificantTextAggregation {
+	agg.shardSize = &size
+	return agg
+}
+
+// MinDocCount sets the minimum number of documents a term must match to be
+// returned. The default is 3.
+func (agg *SignificantTextAggregation) MinDocCount(min uint64) *SignificantTextAggregation {
+	agg.minDocCount = &min
+	return agg
+}
+
+// ShardMinDocCount sets the minimum number of documents a term must match on
+// a shard to be returned by that shard.
+func (agg *SignificantTextAggregation) ShardMinDocCount(min uint64) *SignificantTextAggregation {
+	agg.shardMinDocCount = &min
+	return agg
+}
+
+// BackgroundFilter sets the query defining the background set terms are
+// compared to. The default background is the whole index.
+func (agg *SignificantTextAggregation) BackgroundFilter(filter Mappable) *SignificantTextAggregation {
+	agg.backgroundFilter = filter
+	return agg
+}
+
+// Heuristic sets the heuristic used to score terms.
+func (agg *SignificantTextAggregation) Heuristic(heuristic *SignificanceHeuristic) *SignificantTextAggregation {
+	agg.heuristic = heuristic
+	return agg
+}
+
+// FilterDuplicateText sets whether duplicate sequences of text, such as
+// boilerplate or copied content, are ignored.
+func (agg *SignificantTextAggregation) FilterDuplicateText(b bool) *SignificantTextAggregation {
+	agg.filterDuplicateText = &b
+	return agg
+}
+
+// SourceFields sets the fields of the document source to analyze, when they
+// differ from the aggregated field (e.g. for multi-fields).
+func (agg *SignificantTextAggregation) SourceFields(fields ...string) *SignificantTextAggregation {
+	agg.sourceFields = fields
+	return agg
+}
+
+// Aggs sets sub-aggregations for the aggregation.
+func (agg *SignificantTextAggregation) Aggs(aggs ...Aggregation) *SignificantTextAggregation {
+	agg.aggs = aggs
+	return agg
+}
+
+func (agg *SignificantTextAggregation) subAggs() []Aggregation {
+	return agg.aggs
+}
+
+// Map returns a map representation of the aggregation, thus implementing the
+// Mappable interface.
+func (agg *SignificantTextAggregation) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"field": agg.field,
+	}
+	if agg.size != nil {
+		innerMap["size"] = *agg.size
+	}
+	if agg.shardSize != nil {
+		innerMap["shard_size"] = *agg.shardSize
+	}
+	if agg.minDocCount != nil {
+		innerMap["min_doc_count"] = *agg.minDocCount
+	}
+	if agg.shardMinDocCount != nil {
+		innerMap["shard_min_doc_count"] = *agg.shardMinDocCount
+	}
+	if agg.filterDuplicateText != nil {
+		innerMap["filter_duplicate_text"] = *agg.filterDuplicateText
+	}
+	if len(agg.sourceFields) > 0 {
+		innerMap["source_fields"] = agg.sourceFields
+	}
+
+	return significantMap("significant_text", innerMap, agg.backgroundFilter, agg.heuristic, agg.aggs)
+}
@@ -0,0 +1,102 @@
+package osquery
+
+import "testing"
+
+func TestSignificantAggs(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"significant_terms agg: defaults",
+			SignificantTermsAgg("unusual", "crime_type"),
+			map[string]interface{}{
+				"significant_terms": map[string]interface{}{
+					"field": "crime_type",
+				},
+			},
+		},
+		{
+			"significant_terms agg: all options",
+			SignificantTermsAgg("unusual", "crime_type").
+				Size(5).
+				ShardSize(50).
+				MinDocCount(10).
+				ShardMinDocCount(2).
+				BackgroundFilter(Term("city", "paris")).
+				Heuristic(MutualInformationHeuristic().IncludeNegatives(false).BackgroundIsSuperset(false)).
+				Exclude("other").
+				ExecutionHint(ExecutionHintGlobalOrdinals).
+				Aggs(ValueCount("count", "crime_type")),
+			map[string]interface{}{
+				"significant_terms": map[string]interface{}{
+					"field":               "crime_type",
+					"size":                5,
+					"shard_size":          50,
+					"min_doc_count":       10,
+					"shard_min_doc_count": 2,
+					"background_filter": map[string]interface{}{
+						"term": map[string]interface{}{
+							"city": map[string]interface{}{"value": "paris"},
+						},
+					},
+					"mutual_information": map[string]interface{}{
+						"include_negatives":      false,
+						"background_is_superset": false,
+					},
+					"exclude":        "other",
+					"execution_hint": "global_ordinals",
+				},
+				"aggs": map[string]interface{}{
+					"count": map[string]interface{}{
+						"value_count": map[string]interface{}{
+							"field": "crime_type",
+						},
+					},
+				},
+			},
+		},
+		{
+			"significant_terms agg: script heuristic",
+			SignificantTermsAgg("unusual", "tags").
+				Heuristic(ScriptHeuristic(Script("").Source("params._subset_freq / (params._superset_freq + 1)"))),
+			map[string]interface{}{
+				"significant_terms": map[string]interface{}{
+					"field": "tags",
+					"script_heuristic": map[string]interface{}{
+						"script": map[string]interface{}{
+							"source": "params._subset_freq / (params._superset_freq + 1)",
+						},
+					},
+				},
+			},
+		},
+		{
+			"significant_text agg",
+			SignificantTextAgg("keywords", "content").
+				FilterDuplicateText(true).
+				SourceFields("content", "title").
+				MinDocCount(2).
+				Heuristic(JLHHeuristic()),
+			map[string]interface{}{
+				"significant_text": map[string]interface{}{
+					"field":                 "content",
+					"filter_duplicate_text": true,
+					"source_fields":         []string{"content", "title"},
+					"min_doc_count":         2,
+					"jlh":                   map[string]interface{}{},
+				},
+			},
+		},
+		{
+			"significant_text agg: other heuristics",
+			SignificantTextAgg("keywords", "content").
+				Heuristic(GNDHeuristic().BackgroundIsSuperset(true)),
+			map[string]interface{}{
+				"significant_text": map[string]interface{}{
+					"field": "content",
+					"gnd": map[string]interface{}{
+						"background_is_superset": true,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,448 @@
+package osquery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// BulkActionType is an enumeration type for the action of a bulk item.
+type BulkActionType string
+
+const (
+	// BulkActionIndex indexes a document, replacing it if it already exists.
+	BulkActionIndex BulkActionType = "index"
+
+	// BulkActionCreate indexes a document, failing if it already exists.
+	BulkActionCreate BulkActionType = "create"
+
+	// BulkActionUpdate partially updates an existing document.
+	BulkActionUpdate BulkActionType = "update"
+
+	// BulkActionDelete deletes a document.
+	BulkActionDelete BulkActionType = "delete"
+)
+
+// RefreshPolicy is an enumeration type for the "refresh" parameter of write
+// requests.
+type RefreshPolicy string
+
+const (
+	// RefreshTrue refreshes the affected shards immediately.
+	RefreshTrue RefreshPolicy = "true"
+
+	// RefreshFalse does not refresh the affected shards.
+	RefreshFalse RefreshPolicy = "false"
+
+	// RefreshWaitFor waits for the next scheduled refresh before returning.
+	RefreshWaitFor RefreshPolicy = "wait_for"
+)
+
+// BulkAction represents a single action of a bulk request, as described in:
+// https://opensearch.org/docs/latest/api-reference/document-apis/bulk/
+// All four action types share the same structure, but they don't necessarily
+// support all the same options. The library does not attempt to verify
+// provided options are supported.
+type BulkAction struct {
+	actionType BulkActionType
+	header     bulkActionHeader
+	doc        interface{}
+	upsert     interface{}
+	docUpsert  *bool
+	scriptUp   *bool
+	script     *ScriptField
+}
+
+type bulkActionHeader struct {
+	Index           string `json:"_index,omitempty"`
+	ID              string `json:"_id,omitempty"`
+	Routing         string `json:"routing,omitempty"`
+	Pipeline        string `json:"pipeline,omitempty"`
+	Version         *int64 `json:"version,omitempty"`
+	VersionType     string `json:"version_type,omitempty"`
+	IfSeqNo         *int64 `json:"if_seq_no,omitempty"`
+	IfPrimaryTerm   *int64 `json:"if_primary_term,omitempty"`
+	RequireAlias    *bool  `json:"require_alias,omitempty"`
+	RetryOnConflict *int   `json:"retry_on_conflict,omitempty"`
+}
+
+// BulkIndex creates a new bulk action of type "index" on the provided index.
+// If index is empty, the default index of the bulk request is used.
+func BulkIndex(index string) *BulkAction {
+	return newBulkAction(BulkActionIndex, index, "")
+}
+
+// BulkCreate creates a new bulk action of type "create" on the provided index.
+// If index is empty, the default index of the bulk request is used.
+func BulkCreate(index string) *BulkAction {
+	return newBulkAction(BulkActionCreate, index, "")
+}
+
+// BulkUpdate creates a new bulk action of type "update" for the document with
+// the provided ID, on the provided index.
+func BulkUpdate(index, id string) *BulkAction {
+	return newBulkAction(BulkActionUpdate, index, id)
+}
+
+// BulkDelete creates a new bulk action of type "delete" for the document with
+// the provided ID, on the provided index.
+func BulkDelete(index, id string) *BulkAction {
+	return newBulkAction(BulkActionDelete, index, id)
+}
+
+func newBulkAction(actionType BulkActionType, index, id string) *BulkAction {
+	return &BulkAction{
+		actionType: actionType,
+		header: bulkActionHeader{
+			Index: index,
+			ID:    id,
+		},
+	}
+}
+
+// Type returns the type of the action.
+func (a *BulkAction) Type() BulkActionType {
+	return a.actionType
+}
+
+// ID sets the ID of the document.
+func (a *BulkAction) ID(id string) *BulkAction {
+	a.header.ID = id
+	return a
+}
+
+// Doc sets the document to index or create, or the partial document to merge
+// into the existing one for update actions. The document is serialized with
+// encoding/json, so json.RawMessage can be used for pre-serialized documents.
+func (a *BulkAction) Doc(doc interface{}) *BulkAction {
+	a.doc = doc
+	return a
+}
+
+// Routing sets the routing value used to route the action to a shard.
+func (a *BulkAction) Routing(routing string) *BulkAction {
+	a.header.Routing = routing
+	return a
+}
+
+// Pipeline sets the ingest pipeline used to pre-process the document.
+func (a *BulkAction) Pipeline(pipeline string) *BulkAction {
+	a.header.Pipeline = pipeline
+	return a
+}
+
+// Version sets the explicit version of the document, for optimistic
+// concurrency control.
+func (a *BulkAction) Version(version int64) *BulkAction {
+	a.header.Version = &version
+	return a
+}
+
+// VersionType sets how the version is interpreted, e.g. "external" or
+// "external_gte".
+func (a *BulkAction) VersionType(versionType string) *BulkAction {
+	a.header.VersionType = versionType
+	return a
+}
+
+// IfSeqNo makes the action only succeed if the document has the provided
+// sequence number.
+func (a *BulkAction) IfSeqNo(seqNo int64) *BulkAction {
+	a.header.IfSeqNo = &seqNo
+	return a
+}
+
+// IfPrimaryTerm makes the action only succeed if the document has the provided
+// primary term.
+func (a *BulkAction) IfPrimaryTerm(primaryTerm int64) *BulkAction {
+	a.header.IfPrimaryTerm = &primaryTerm
+	return a
+}
+
+// RequireAlias sets whether the target index must be an alias.
+func (a *BulkAction) RequireAlias(b bool) *BulkAction {
+	a.header.RequireAlias = &b
+	return a
+}
+
+// RetryOnConflict sets how many times an update action is retried when a
+// version conflict occurs.
+func (a *BulkAction) RetryOnConflict(retries int) *BulkAction {
+	a.header.RetryOnConflict = &retries
+	return a
+}
+
+// Upsert sets the document to index if the document to update does not exist.
+func (a *BulkAction) Upsert(doc interface{}) *BulkAction {
+	a.upsert = doc
+	return a
+}
+
+// DocAsUpsert sets whether the partial document of an update action is
+// indexed if the document does not exist.
+func (a *BulkAction) DocAsUpsert(b bool) *BulkAction {
+	a.docUpsert = &b
+	return a
+}
+
+// ScriptedUpsert sets whether the script of an update action is run whether
+// or not the document exists.
+func (a *BulkAction) ScriptedUpsert(b bool) *BulkAction {
+	a.scriptUp = &b
+	return a
+}
+
+// Script sets the script of an update action.
+func (a *BulkAction) Script(script *ScriptField) *BulkAction {
+	a.script = script
+	return a
+}
+
+// body returns the body line of the action, if it has one.
+func (a *BulkAction) body() (interface{}, bool) {
+	switch a.actionType {
+	case BulkActionDelete:
+		return nil, false
+	case BulkActionUpdate:
+		body := make(map[string]interface{})
+		if a.doc != nil {
+			body["doc"] = a.doc
+		}
+		if a.upsert != nil {
+			body["upsert"] = a.upsert
+		}
+		if a.docUpsert != nil {
+			body["doc_as_upsert"] = *a.docUpsert
+		}
+		if a.scriptUp != nil {
+			body["scripted_upsert"] = *a.scriptUp
+		}
+		if a.script != nil {
+			body["script"] = a.script.Map()["script"]
+		}
+		return body, true
+	default:
+		return a.doc, true
+	}
+}
+
+// encode serializes the action into its NDJSON lines. It fails if an index,
+// create or update action has no document to send.
+func (a *BulkAction) encode() ([]byte, error) {
+	if a.actionType == BulkActionUpdate && a.doc == nil && a.script == nil {
+		return nil, fmt.Errorf("update action requires a document or a script")
+	}
+
+	var buf bytes.Buffer
+	err := writeNDJSON(&buf, map[BulkActionType]bulkActionHeader{
+		a.actionType: a.header,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	body, ok := a.body()
+	if !ok {
+		return buf.Bytes(), nil
+	}
+
+	line, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(line, []byte("null")) {
+		return nil, fmt.Errorf("%s action requires a document", a.actionType)
+	}
+	buf.Write(line)
+	buf.WriteByte('\n')
+
+	return buf.Bytes(), nil
+}
+
+//----------------------------------------------------------------------------//
+
+// BulkRequest represents a request to OpenSearch's Bulk API, as described in:
+// https://opensearch.org/docs/latest/api-reference/document-apis/bulk/
+type BulkRequest struct {
+	index    string
+	actions  []*BulkAction
+	refresh  RefreshPolicy
+	pipeline string
+	routing  string
+}
+
+// Bulk creates a new BulkRequest object, to be filled via method chaining.
+func Bulk() *BulkRequest {
+	return &BulkRequest{}
+}
+
+// Index sets the default index for actions that don't specify one.
+func (req *BulkRequest) Index(index string) *BulkRequest {
+	req.index = index
+	return req
+}
+
+// Add appends one or more actions to the request.
+func (req *BulkRequest) Add(actions ...*BulkAction) *BulkRequest {
+	req.actions = append(req.actions, actions...)
+	return req
+}
+
+// Refresh sets whether and how the affected shards are refreshed.
+func (req *BulkRequest) Refresh(refresh RefreshPolicy) *BulkRequest {
+	req.refresh = refresh
+	return req
+}
+
+// Pipeline sets the default ingest pipeline for actions that don't specify
+// one.
+func (req *BulkRequest) Pipeline(pipeline string) *BulkRequest {
+	req.pipeline = pipeline
+	return req
+}
+
+// Routing sets the default routing value for actions that don't specify one.
+func (req *BulkRequest) Routing(routing string) *BulkRequest {
+	req.routing = routing
+	return req
+}
+
+// NumberOfActions returns the number of actions in the request.
+func (req *BulkRequest) NumberOfActions() int {
+	return len(req.actions)
+}
+
+// Body serializes the request into its NDJSON body.
+func (req *BulkRequest) Body() ([]byte, error) {
+	var buf bytes.Buffer
+	for i, action := range req.actions {
+		b, err := action.encode()
+		if err != nil {
+			return nil, fmt.Errorf("action %d: %w", i, err)
+		}
+		buf.Write(b)
+	}
+	return buf.Bytes(), nil
+}
+
+// Run executes the request using the provided OpenSearch client. Failures of
+// single actions are not returned as an error; they are reported by the items
+// of the response.
+func (req *BulkRequest) Run(
+	ctx context.Context,
+	client *opensearch.Client,
+	options *Options,
+) (*BulkResponse, error) {
+	if len(req.actions) == 0 {
+		return nil, fmt.Errorf("bulk request has no actions")
+	}
+
+	body, err := req.Body()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize request body: %w", err)
+	}
+
+	// Create a BulkReq with the request body
+	bulkReq := opensearchapi.BulkReq{
+		Body: bytes.NewReader(body),
+	}
+
+	// Apply additional options if provided
+	err = ApplyOptions(&bulkReq, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.index != "" {
+		bulkReq.Index = req.index
+	}
+	if req.refresh != "" {
+		bulkReq.Params.Refresh = string(req.refresh)
+	}
+	if req.pipeline != "" {
+		bulkReq.Params.Pipeline = req.pipeline
+	}
+	if req.routing != "" {
+		bulkReq.Params.Routing = req.routing
+	}
+
+	var bulkResp BulkResponse
+	if err := execute(ctx, client, bulkReq, &bulkResp); err != nil {
+		return nil, fmt.Errorf("bulk request failed: %w", err)
+	}
+
+	return &bulkResp, nil
+}
+
+//----------------------------------------------------------------------------//
+
+// BulkResponse represents the response of a bulk request. Items holds the
+// result of every action, in the order the actions were added.
+type BulkResponse struct {
+	Took   int                `json:"took"`
+	Errors bool               `json:"errors"`
+	Items  []BulkResponseItem `json:"items"`
+}
+
+// BulkResponseItem is the result of a single action of a bulk request.
+type BulkResponseItem struct {
+	Action      BulkActionType               `json:"-"`
+	Index       string                       `json:"_index"`
+	ID          string                       `json:"_id"`
+	Version     int64                        `json:"_version"`
+	Result      string                       `json:"result"`
+	Shards      opensearchapi.ResponseShards `json:"_shards"`
+	SeqNo       int64                        `json:"_seq_no"`
+	PrimaryTerm int64                        `json:"_primary_term"`
+	Status      int                          `json:"status"`
+	Error       *ErrorCause                  `json:"error,omitempty"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Each item of the
+// response is an object keyed by the action type, e.g. {"index": {...}}.
+func (item *BulkResponseItem) UnmarshalJSON(data []byte) error {
+	type plain BulkResponseItem
+
+	var wrapped map[BulkActionType]plain
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return err
+	}
+
+	for action, res := range wrapped {
+		*item = BulkResponseItem(res)
+		item.Action = action
+	}
+
+	return nil
+}
+
+// Failed returns whether the action failed.
+func (item *BulkResponseItem) Failed() bool {
+	return item.Error != nil || item.Status > 299
+}
+
+// Err returns the error of the action, or nil if it succeeded.
+func (item *BulkResponseItem) Err() error {
+	if !item.Failed() {
+		return nil
+	}
+	return &ResponseError{
+		StatusCode: item.Status,
+		Cause:      item.Error,
+	}
+}
+
+// Failed returns the items of the actions that failed.
+func (res *BulkResponse) Failed() []BulkResponseItem {
+	var failed []BulkResponseItem
+	for _, item := range res.Items {
+		if item.Failed() {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,390 @@
+package osquery
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// ErrBulkIndexerClosed is returned when adding actions to a closed
+// BulkIndexer.
+var ErrBulkIndexerClosed = errors.New("bulk indexer is closed")
+
+// BulkIndexerConfig holds the configuration of a BulkIndexer. Zero values are
+// replaced with defaults.
+type BulkIndexerConfig struct {
+	// Index is the default index for actions that don't specify one.
+	Index string
+
+	// Workers is the number of goroutines sending bulk requests concurrently.
+	// Defaults to the number of CPUs.
+	Workers int
+
+	// FlushBytes is the size of the request body, in bytes, at which a worker
+	// flushes its pending actions. Defaults to 5MB.
+	FlushBytes int
+
+	// FlushActions is the number of pending actions at which a worker flushes
+	// them. Defaults to 1000.
+	FlushActions int
+
+	// FlushInterval is the maximum time actions stay pending before being
+	// flushed. Defaults to 30 seconds.
+	FlushInterval time.Duration
+
+	// MaxRetries is the maximum number of times actions rejected with a 429
+	// (Too Many Requests) status are retried. Defaults to 3; a negative value
+	// disables retries.
+	MaxRetries int
+
+	// RetryBackoff returns how long to wait before the provided retry attempt
+	// (starting at 1). Defaults to an exponential backoff starting at 100ms.
+	RetryBackoff func(attempt int) time.Duration
+
+	// Options are applied to every bulk request, as in BulkRequest.Run.
+	Options *Options
+
+	// OnSuccess is called for every action that succeeded.
+	OnSuccess func(ctx context.Context, action *BulkAction, item BulkResponseItem)
+
+	// OnFailure is called for every action that failed. If the whole bulk
+	// request failed, item is nil and err describes the failure; otherwise
+	// err is the item's error.
+	OnFailure func(ctx context.Context, action *BulkAction, item *BulkResponseItem, err error)
+}
+
+// BulkIndexerStats holds counters of a BulkIndexer's activity.
+type BulkIndexerStats struct {
+	NumAdded    uint64
+	NumFlushed  uint64
+	NumFailed   uint64
+	NumIndexed  uint64
+	NumCreated  uint64
+	NumUpdated  uint64
+	NumDeleted  uint64
+	NumRequests uint64
+	NumRetries  uint64
+}
+
+// BulkIndexer indexes documents in the background, grouping the actions added
+// to it into bulk requests sent by several concurrent workers. Each worker
+// flushes its pending actions once they reach a number of actions or a body
+// size, or when the flush interval elapses. Actions rejected by OpenSearch
+// with a 429 (Too Many Requests) status are retried with backoff, and the
+// outcome of every action is reported via the OnSuccess and OnFailure
+// callbacks.
+type BulkIndexer struct {
+	client *opensearch.Client
+	config BulkIndexerConfig
+
+	queue  chan bulkIndexerItem
+	wg     sync.WaitGroup
+	mu     sync.RWMutex
+	closed bool
+	ctx    context.Context
+	cancel context.CancelFunc
+	stats  bulkIndexerStats
+}
+
+type bulkIndexerItem struct {
+	action *BulkAction
+	data   []byte
+}
+
+type bulkIndexerStats struct {
+	numAdded    atomic.Uint64
+	numFlushed  atomic.Uint64
+	numFailed   atomic.Uint64
+	numIndexed  atomic.Uint64
+	numCreated  atomic.Uint64
+	numUpdated  atomic.Uint64
+	numDeleted  atomic.Uint64
+	numRequests atomic.Uint64
+	numRetries  atomic.Uint64
+}
+
+// NewBulkIndexer creates a new BulkIndexer and starts its workers. Close must
+// be called to flush pending actions and stop the workers.
+func NewBulkIndexer(client *opensearch.Client, config BulkIndexerConfig) *BulkIndexer {
+	if config.Workers <= 0 {
+		config.Workers = runtime.NumCPU()
+	}
+	if config.FlushBytes <= 0 {
+		config.FlushBytes = 5e6
+	}
+	if config.FlushActions <= 0 {
+		config.FlushActions = 1000
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = 30 * time.Second
+	}
+	if config.MaxRetries == 0 {
+		config.MaxRetries = 3
+	}
+	if config.RetryBackoff == nil {
+		config.RetryBackoff = defaultRetryBackoff
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bi := &BulkIndexer{
+		client: client,
+		config: config,
+		queue:  make(chan bulkIndexerItem, config.Workers),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	for i := 0; i < config.Workers; i++ {
+		bi.wg.Add(1)
+		go bi.work()
+	}
+
+	return bi
+}
+
+// defaultRetryBackoff doubles the wait time on every attempt, starting at
+// 100ms and capping at 10s.
+func defaultRetryBackoff(attempt int) time.Duration {
+	d := 100 * time.Millisecond << (attempt - 1)
+	if d <= 0 || d > 10*time.Second {
+		return 10 * time.Second
+	}
+	return d
+}
+
+// Add queues an action. It blocks until a worker accepts the action or the
+// context is done.
+func (bi *BulkIndexer) Add(ctx context.Context, action *BulkAction) error {
+	data, err := action.encode()
+	if err != nil {
+		return fmt.Errorf("failed to serialize action: %w", err)
+	}
+
+	bi.mu.RLock()
+	defer bi.mu.RUnlock()
+
+	if bi.closed {
+		return ErrBulkIndexerClosed
+	}
+
+	select {
+	case bi.queue <- bulkIndexerItem{action, data}:
+		bi.stats.numAdded.Add(1)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting new actions, flushes pending ones and waits for the
+// workers to finish. If the context is done first, in-flight requests are
+// canceled and the context's error is returned.
+func (bi *BulkIndexer) Close(ctx context.Context) error {
+	bi.mu.Lock()
+	if !bi.closed {
+		bi.closed = true
+		close(bi.queue)
+	}
+	bi.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		bi.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		bi.cancel()
+		return nil
+	case <-ctx.Done():
+		bi.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// Stats returns the current counters of the indexer.
+func (bi *BulkIndexer) Stats() BulkIndexerStats {
+	return BulkIndexerStats{
+		NumAdded:    bi.stats.numAdded.Load(),
+		NumFlushed:  bi.stats.numFlushed.Load(),
+		NumFailed:   bi.stats.numFailed.Load(),
+		NumIndexed:  bi.stats.numIndexed.Load(),
+		NumCreated:  bi.stats.numCreated.Load(),
+		NumUpdated:  bi.stats.numUpdated.Load(),
+		NumDeleted:  bi.stats.numDeleted.Load(),
+		NumRequests: bi.stats.numRequests.Load(),
+		NumRetries:  bi.stats.numRetries.Load(),
+	}
+}
+
+// work runs a worker, accumulating actions until they need to be flushed.
+func (bi *BulkIndexer) work() {
+	defer bi.wg.Done()
+
+	ticker := time.NewTicker(bi.config.FlushInterval)
+	defer ticker.Stop()
+
+	var (
+		pending []bulkIndexerItem
+		size    int
+	)
+	flush := func() {
+		if len(pending) > 0 {
+			bi.flush(bi.ctx, pending)
+		}
+		pending = nil
+		size = 0
+	}
+
+	for {
+		select {
+		case item, ok := <-bi.queue:
+			if !ok {
+				flush()
+				return
+			}
+			if len(pending) > 0 && size+len(item.data) > bi.config.FlushBytes {
+				flush()
+			}
+			pending = append(pending, item)
+			size += len(item.data)
+			if len(pending) >= bi.config.FlushActions || size >= bi.config.FlushBytes {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// flush sends the provided items in a bulk request, retrying those rejected
+// with a 429 status, and reports the outcome of each of them.
+func (bi *BulkIndexer) flush(ctx context.Context, items []bulkIndexerItem) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			bi.stats.numRetries.Add(uint64(len(items)))
+			select {
+			case <-time.After(bi.config.RetryBackoff(attempt)):
+			case <-ctx.Done():
+				bi.failAll(ctx, items, ctx.Err())
+				return
+			}
+		}
+
+		canRetry := attempt < bi.config.MaxRetries
+
+		res, err := bi.send(ctx, items)
+		if err != nil {
+			var resErr *ResponseError
+			if canRetry && errors.As(err, &resErr) && resErr.StatusCode == http.StatusTooManyRequests {
+				continue
+			}
+			bi.failAll(ctx, items, err)
+			return
+		}
+
+		if len(res.Items) != len(items) {
+			bi.failAll(ctx, items, fmt.Errorf(
+				"bulk response has %d items, expected %d", len(res.Items), len(items),
+			))
+			return
+		}
+
+		var retry []bulkIndexerItem
+		for i, item := range res.Items {
+			if canRetry && item.Status == http.StatusTooManyRequests {
+				retry = append(retry, items[i])
+				continue
+			}
+			bi.report(ctx, items[i].action, item)
+		}
+
+		if len(retry) == 0 {
+			return
+		}
+		items = retry
+	}
+}
+
+// send executes a bulk request for the provided items.
+func (bi *BulkIndexer) send(ctx context.Context, items []bulkIndexerItem) (*BulkResponse, error) {
+	var body bytes.Buffer
+	for _, item := range items {
+		body.Write(item.data)
+	}
+
+	bulkReq := opensearchapi.BulkReq{
+		Body: &body,
+	}
+
+	// Apply additional options if provided
+	err := ApplyOptions(&bulkReq, bi.config.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	if bi.config.Index != "" {
+		bulkReq.Index = bi.config.Index
+	}
+
+	bi.stats.numRequests.Add(1)
+
+	var bulkResp BulkResponse
+	if err := execute(ctx, bi.client, bulkReq, &bulkResp); err != nil {
+		return nil, fmt.Errorf("bulk request failed: %w", err)
+	}
+
+	return &bulkResp, nil
+}
+
+// report updates the stats and calls the callbacks for a single action.
+func (bi *BulkIndexer) report(ctx context.Context, action *BulkAction, item BulkResponseItem) {
+	bi.stats.numFlushed.Add(1)
+
+	if item.Failed() {
+		bi.stats.numFailed.Add(1)
+		if bi.config.OnFailure != nil {
+			bi.config.OnFailure(ctx, action, &item, item.Err())
+		}
+		return
+	}
+
+	switch item.Action {
+	case BulkActionIndex:
+		bi.stats.numIndexed.Add(1)
+	case BulkActionCreate:
+		bi.stats.numCreated.Add(1)
+	case BulkActionUpdate:
+		bi.stats.numUpdated.Add(1)
+	case BulkActionDelete:
+		bi.stats.numDeleted.Add(1)
+	}
+
+	if bi.config.OnSuccess != nil {
+		bi.config.OnSuccess(ctx, action, item)
+	}
+}
+
+// failAll reports all the provided items as failed with the provided error.
+func (bi *BulkIndexer) failAll(ctx context.Context, items []bulkIndexerItem, err error) {
+	bi.stats.numFlushed.Add(uint64(len(items)))
+	bi.stats.numFailed.Add(uint64(len(items)))
+
+	if bi.config.OnFailure == nil {
+		return
+	}
+	for _, item := range items {
+		bi.config.OnFailure(ctx, item.action, nil, err)
+	}
+}
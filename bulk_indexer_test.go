@@ -0,0 +1,117 @@
+package osquery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jgroeneveld/trial/assert"
+)
+
+// bulkItemsResponse answers a bulk request with one item per action, using
+// the status returned by status for each document ID.
+func bulkItemsResponse(body string, status func(id string) int) string {
+	var items []string
+	for _, line := range strings.Split(strings.TrimSpace(body), "\n") {
+		if !strings.HasPrefix(line, `{"index":`) {
+			continue
+		}
+		id := strings.TrimSuffix(strings.SplitN(line, `"_id":"`, 2)[1], `"}}`)
+		code := status(id)
+		errObj := ""
+		if code > 299 {
+			errObj = `,"error":{"type":"rejected_execution_exception","reason":"rejected"}`
+		}
+		items = append(items, fmt.Sprintf(
+			`{"index":{"_index":"books","_id":"%s","status":%d%s}}`, id, code, errObj,
+		))
+	}
+	return fmt.Sprintf(`{"took":1,"errors":false,"items":[%s]}`, strings.Join(items, ","))
+}
+
+func TestBulkIndexer(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		rejected = map[string]bool{}
+	)
+	client, requests := newTestClient(t, func(_ int, req recordedRequest) (int, string) {
+		return 200, bulkItemsResponse(req.Body, func(id string) int {
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case id == "bad":
+				return 400
+			case id == "3" && !rejected[id]:
+				// reject the first attempt of document 3
+				rejected[id] = true
+				return 429
+			default:
+				return 201
+			}
+		})
+	})
+
+	var (
+		failedMu sync.Mutex
+		failed   []string
+	)
+	bi := NewBulkIndexer(client, BulkIndexerConfig{
+		Index:         "books",
+		Workers:       1,
+		FlushActions:  2,
+		FlushInterval: time.Hour,
+		RetryBackoff:  func(int) time.Duration { return time.Millisecond },
+		OnFailure: func(_ context.Context, action *BulkAction, item *BulkResponseItem, err error) {
+			failedMu.Lock()
+			defer failedMu.Unlock()
+			failed = append(failed, item.ID)
+		},
+	})
+
+	ctx := context.Background()
+	for _, id := range []string{"1", "2", "3", "bad", "5"} {
+		err := bi.Add(ctx, BulkIndex("").ID(id).Doc(map[string]interface{}{"id": id}))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, bi.Close(ctx))
+	assert.Equal(t, ErrBulkIndexerClosed, bi.Add(ctx, BulkIndex("").ID("6").Doc(map[string]interface{}{"id": "6"})))
+
+	stats := bi.Stats()
+	assert.Equal(t, uint64(5), stats.NumAdded)
+	assert.Equal(t, uint64(5), stats.NumFlushed)
+	assert.Equal(t, uint64(4), stats.NumIndexed)
+	assert.Equal(t, uint64(1), stats.NumFailed)
+	assert.Equal(t, uint64(1), stats.NumRetries)
+	assert.Equal(t, uint64(4), stats.NumRequests)
+	assert.DeepEqual(t, []string{"bad"}, failed)
+
+	reqs := requests()
+	assert.Equal(t, "/books/_bulk", reqs[0].Path)
+	// the retry only holds the rejected document
+	assert.Equal(t, 1, strings.Count(reqs[2].Body, `"index"`))
+}
+
+func TestBulkIndexerFlushInterval(t *testing.T) {
+	flushed := make(chan struct{}, 1)
+	client, _ := newTestClient(t, func(_ int, req recordedRequest) (int, string) {
+		flushed <- struct{}{}
+		return 200, bulkItemsResponse(req.Body, func(string) int { return 201 })
+	})
+
+	bi := NewBulkIndexer(client, BulkIndexerConfig{
+		Workers:       1,
+		FlushInterval: 10 * time.Millisecond,
+	})
+	defer bi.Close(context.Background())
+
+	assert.Nil(t, bi.Add(context.Background(), BulkIndex("books").ID("1").Doc(struct{}{})))
+
+	select {
+	case <-flushed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("pending actions were not flushed")
+	}
+}
@@ -0,0 +1,93 @@
+package osquery
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jgroeneveld/trial/assert"
+)
+
+func TestBulkBody(t *testing.T) {
+	body, err := Bulk().
+		Add(
+			BulkIndex("books").ID("1").Doc(map[string]interface{}{"title": "Go"}).Pipeline("enrich"),
+			BulkCreate("").ID("2").Doc(json.RawMessage(`{"title": "Rust"}`)).Routing("user1"),
+			BulkUpdate("books", "3").
+				Doc(map[string]interface{}{"views": 1}).
+				DocAsUpsert(true).
+				RetryOnConflict(3),
+			BulkUpdate("books", "4").
+				Script(Script("").Source("ctx._source.views += params.n").Params(ScriptParams{"n": 1})).
+				Upsert(map[string]interface{}{"views": 1}),
+			BulkDelete("books", "5").Version(7).VersionType("external"),
+			BulkIndex("books").ID("6").Doc(map[string]interface{}{}).IfSeqNo(10).IfPrimaryTerm(2),
+		).
+		Body()
+	assert.Nil(t, err)
+
+	assert.Equal(t, ``+
+		`{"index":{"_index":"books","_id":"1","pipeline":"enrich"}}`+"\n"+
+		`{"title":"Go"}`+"\n"+
+		`{"create":{"_id":"2","routing":"user1"}}`+"\n"+
+		`{"title":"Rust"}`+"\n"+
+		`{"update":{"_index":"books","_id":"3","retry_on_conflict":3}}`+"\n"+
+		`{"doc":{"views":1},"doc_as_upsert":true}`+"\n"+
+		`{"update":{"_index":"books","_id":"4"}}`+"\n"+
+		`{"script":{"params":{"n":1},"source":"ctx._source.views += params.n"},"upsert":{"views":1}}`+"\n"+
+		`{"delete":{"_index":"books","_id":"5","version":7,"version_type":"external"}}`+"\n"+
+		`{"index":{"_index":"books","_id":"6","if_seq_no":10,"if_primary_term":2}}`+"\n"+
+		`{}`+"\n",
+		string(body),
+	)
+
+	for _, action := range []*BulkAction{
+		BulkIndex("books").ID("1"),
+		BulkCreate("books").ID("1").Doc((*iteratorDoc)(nil)),
+		BulkUpdate("books", "1").Upsert(map[string]interface{}{"views": 1}),
+	} {
+		_, err := Bulk().Add(action).Body()
+		assert.NotNil(t, err)
+	}
+}
+
+func TestBulkRun(t *testing.T) {
+	client, requests := newTestClient(t, func(int, recordedRequest) (int, string) {
+		return 200, `{"took":2,"errors":true,"items":[
+			{"index":{"_index":"books","_id":"1","_version":1,"result":"created","status":201}},
+			{"delete":{"_index":"books","_id":"2","status":404,"result":"not_found"}},
+			{"create":{"_index":"books","_id":"3","status":409,"error":{
+				"type":"version_conflict_engine_exception",
+				"reason":"[3]: version conflict, document already exists"
+			}}}
+		]}`
+	})
+
+	res, err := Bulk().
+		Index("books").
+		Refresh(RefreshWaitFor).
+		Add(
+			BulkIndex("").ID("1").Doc(map[string]interface{}{"title": "Go"}),
+			BulkDelete("", "2"),
+			BulkCreate("").ID("3").Doc(map[string]interface{}{"title": "Rust"}),
+		).
+		Run(context.Background(), client, nil)
+	assert.Nil(t, err)
+	assert.True(t, res.Errors)
+	assert.Equal(t, 3, len(res.Items))
+	assert.Equal(t, BulkActionIndex, res.Items[0].Action)
+	assert.Equal(t, "created", res.Items[0].Result)
+	assert.Nil(t, res.Items[0].Err())
+
+	failed := res.Failed()
+	assert.Equal(t, 2, len(failed))
+	assert.Equal(t, BulkActionCreate, failed[1].Action)
+	assert.Equal(t, "version_conflict_engine_exception", failed[1].Error.Type)
+
+	reqs := requests()
+	assert.Equal(t, "/books/_bulk", reqs[0].Path)
+	assert.Equal(t, "refresh=wait_for", reqs[0].Query)
+
+	_, err = Bulk().Run(context.Background(), client, nil)
+	assert.NotNil(t, err)
+}
@@ -1,7 +1,8 @@
 package osquery
 
 type Collapse struct {
-	field string
+	field     string
+	innerHits []*InnerHitsOptions
 	Mappable
 }
 
@@ -11,12 +12,33 @@ func CollapseField(field string) Collapse {
 	}
 }
 
+// InnerHits sets inner hits returning the documents of each collapsed group.
+// Several inner hits may be set, in which case each must have a distinct
+// name.
+func (c Collapse) InnerHits(innerHits ...*InnerHitsOptions) Collapse {
+	c.innerHits = innerHits
+	return c
+}
+
+// Map returns a map representation of the collapse clause, thus implementing
+// the Mappable interface. The map is empty if no field is set, as inner hits
+// are meaningless without one.
 func (c Collapse) Map() map[string]interface{} {
-	outerMap := make(map[string]interface{})
-	if c.field != "" {
-		outerMap = map[string]interface{}{
-			"field": c.field,
+	if c.field == "" {
+		return map[string]interface{}{}
+	}
+
+	outerMap := map[string]interface{}{
+		"field": c.field,
+	}
+	if len(c.innerHits) == 1 {
+		outerMap["inner_hits"] = c.innerHits[0].Map()
+	} else if len(c.innerHits) > 1 {
+		innerHits := make([]map[string]interface{}, len(c.innerHits))
+		for i, ih := range c.innerHits {
+			innerHits[i] = ih.Map()
 		}
+		outerMap["inner_hits"] = innerHits
 	}
 	return outerMap
 }
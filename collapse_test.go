@@ -13,3 +13,50 @@ func TestCollapse(t *testing.T) {
 		},
 	})
 }
+
+func TestCollapseInnerHits(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"collapse with inner_hits",
+			CollapseField("user").InnerHits(InnerHits().Name("recent").Size(2)),
+			map[string]interface{}{
+				"field": "user",
+				"inner_hits": map[string]interface{}{
+					"name": "recent",
+					"size": 2,
+				},
+			},
+		},
+		{
+			"collapse with several inner_hits",
+			Search().Collapse(CollapseField("user").InnerHits(
+				InnerHits().Name("recent").Sort(FieldSort("date").Order(OrderDesc)),
+				InnerHits().Name("oldest").Sort(FieldSort("date").Order(OrderAsc)),
+			)),
+			map[string]interface{}{
+				"collapse": map[string]interface{}{
+					"field": "user",
+					"inner_hits": []map[string]interface{}{
+						{
+							"name": "recent",
+							"sort": []map[string]interface{}{
+								{"date": map[string]interface{}{"order": "desc"}},
+							},
+						},
+						{
+							"name": "oldest",
+							"sort": []map[string]interface{}{
+								{"date": map[string]interface{}{"order": "asc"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"collapse with inner_hits and no field",
+			Search().Collapse(CollapseField("").InnerHits(InnerHits().Name("recent"))),
+			map[string]interface{}{},
+		},
+	})
+}
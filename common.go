@@ -4,6 +4,11 @@
 
 package osquery
 
+import (
+	"strconv"
+	"time"
+)
+
 // Source represents the "_source" option which is commonly accepted in OS
 // queries. Currently, only the "includes" option is supported.
 type Source struct {
@@ -22,3 +27,9 @@ func (source Source) Map() map[string]any {
 	}
 	return m
 }
+
+// formatDuration formats a duration as a time unit string accepted by
+// OpenSearch, using milliseconds as the unit.
+func formatDuration(d time.Duration) string {
+	return strconv.FormatInt(d.Milliseconds(), 10) + "ms"
+}
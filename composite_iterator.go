@@ -0,0 +1,139 @@
+package osquery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/opensearch-project/opensearch-go/v4"
+)
+
+// CompositeIterator pages through all buckets of a composite aggregation,
+// executing its search request repeatedly with the "after_key" of the
+// previous page until no buckets are left.
+//
+// Iterators are used like this:
+//
+//	agg := osquery.CompositeAgg("combos",
+//		osquery.CompositeTerms("city", "city"),
+//		osquery.CompositeTerms("category", "category"),
+//	).Size(1000)
+//	it := osquery.IterateComposite(osquery.Search().Query(q), agg, client, options)
+//	for it.Next(ctx) {
+//		bucket := it.Bucket()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+type CompositeIterator struct {
+	req     *SearchRequest
+	agg     *CompositeAggregation
+	client  *opensearch.Client
+	options *Options
+
+	page []CompositeBucket
+	pos  int
+	done bool
+	err  error
+}
+
+// IterateComposite creates a new CompositeIterator over all buckets of the
+// provided composite aggregation, which is added to the search request if it
+// isn't already part of it. Neither the request nor the aggregation are
+// modified by the iterator. Hits are not fetched, as only the aggregation's
+// buckets are of interest.
+func IterateComposite(
+	req *SearchRequest,
+	agg *CompositeAggregation,
+	client *opensearch.Client,
+	options *Options,
+) *CompositeIterator {
+	c := *agg
+	it := &CompositeIterator{
+		req:     req.clone(),
+		agg:     &c,
+		client:  client,
+		options: options,
+	}
+
+	found := false
+	for i, a := range it.req.aggs {
+		if a == Aggregation(agg) {
+			it.req.aggs[i] = it.agg
+			found = true
+		}
+	}
+	if !found {
+		it.req.aggs = append(it.req.aggs, it.agg)
+	}
+	it.req.Size(0)
+
+	return it
+}
+
+// Next advances the iterator to the next bucket, fetching a new page when
+// needed. It returns false once all buckets were consumed, or if an error
+// occurred, in which case Err returns it.
+func (it *CompositeIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	if it.pos+1 < len(it.page) {
+		it.pos++
+		return true
+	}
+
+	if it.done {
+		return false
+	}
+
+	if err := it.fetch(ctx); err != nil {
+		it.err = err
+		it.page = nil
+		return false
+	}
+
+	return len(it.page) > 0
+}
+
+// Bucket returns the current bucket. It must only be called after Next
+// returned true.
+func (it *CompositeIterator) Bucket() *CompositeBucket {
+	if it.pos >= len(it.page) {
+		return nil
+	}
+	return &it.page[it.pos]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *CompositeIterator) Err() error {
+	return it.err
+}
+
+func (it *CompositeIterator) fetch(ctx context.Context) error {
+	searchReq, err := it.req.searchReq(it.options)
+	if err != nil {
+		return err
+	}
+
+	var res SearchResult[json.RawMessage]
+	if err := execute(ctx, it.client, searchReq, &res); err != nil {
+		return fmt.Errorf("search request failed: %w", err)
+	}
+
+	result, err := res.Aggregations.Composite(it.agg)
+	if err != nil {
+		return fmt.Errorf("composite aggregation %q: %w", it.agg.name, err)
+	}
+
+	it.page = result.Buckets
+	it.pos = 0
+	it.agg.after = result.AfterKey
+	if len(it.page) == 0 || result.AfterKey == nil {
+		it.done = true
+	}
+
+	return nil
+}
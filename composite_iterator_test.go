@@ -0,0 +1,80 @@
+package osquery
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jgroeneveld/trial/assert"
+)
+
+func TestCompositeIterator(t *testing.T) {
+	client, requests := newTestClient(t, func(n int, _ recordedRequest) (int, string) {
+		switch n {
+		case 0:
+			return 200, `{"hits":{"hits":[]},"aggregations":{"combos":{
+				"after_key":{"city":"Paris","day":1709251200000},
+				"buckets":[
+					{"key":{"city":"Lyon","day":1709251200000},"doc_count":3,"views":{"value":12}},
+					{"key":{"city":"Paris","day":1709251200000},"doc_count":1,"views":{"value":4}}
+				]}}}`
+		case 1:
+			return 200, `{"hits":{"hits":[]},"aggregations":{"combos":{
+				"after_key":{"city":"Rome","day":1709251200000},
+				"buckets":[{"key":{"city":"Rome","day":1709251200000},"doc_count":2}]}}}`
+		default:
+			return 200, `{"hits":{"hits":[]},"aggregations":{"combos":{"buckets":[]}}}`
+		}
+	})
+
+	agg := CompositeAgg("combos",
+		CompositeTerms("city", "city"),
+		CompositeDateHistogram("day", "published_at").CalendarInterval("day"),
+	).Size(2).Aggs(Sum("views", "views"))
+	req := Search().Query(MatchAll()).Aggs(agg)
+
+	it := IterateComposite(req, agg, client, &Options{Indices: []string{"events"}})
+
+	var cities []interface{}
+	for it.Next(context.Background()) {
+		cities = append(cities, it.Bucket().Key["city"])
+	}
+	assert.Nil(t, it.Err())
+	assert.DeepEqual(t, []interface{}{"Lyon", "Paris", "Rome"}, cities)
+
+	reqs := requests()
+	assert.Equal(t, 3, len(reqs))
+	assert.Equal(t, "/events/_search", reqs[0].Path)
+
+	var first map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(reqs[0].Body), &first))
+	assert.Equal(t, 0.0, first["size"])
+
+	// the epoch must be sent back exactly as received
+	assert.True(t, strings.Contains(reqs[1].Body, `"after":{"city":"Paris","day":1709251200000}`))
+	assert.True(t, strings.Contains(reqs[2].Body, `"after":{"city":"Rome","day":1709251200000}`))
+
+	// neither the request nor the aggregation were modified
+	assert.True(t, agg.after == nil)
+	assert.True(t, req.size == nil)
+}
+
+func TestCompositeResult(t *testing.T) {
+	agg := CompositeAgg("combos", CompositeTerms("city", "city"))
+
+	aggs, err := ParseAggregations(json.RawMessage(`{"combos":{
+		"after_key":{"city":"Rome"},
+		"buckets":[{"key":{"city":"Rome"},"doc_count":2,"views":{"value":7}}]
+	}}`))
+	assert.Nil(t, err)
+
+	res, err := aggs.Composite(agg)
+	assert.Nil(t, err)
+	assert.Equal(t, "Rome", res.AfterKey["city"])
+	assert.Equal(t, int64(2), res.Buckets[0].DocCount)
+
+	views, err := res.Buckets[0].Aggregations.Sum(Sum("views", "views"))
+	assert.Nil(t, err)
+	assert.Equal(t, 7.0, *views.Value)
+}
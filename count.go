@@ -21,7 +21,8 @@ type CountRequest struct {
 	Query Mappable
 }
 
-// Count creates a new count request with the provided query.
+// Count creates a new count request with the provided query. The query may be
+// nil, in which case all documents are counted.
 func Count(q Mappable) *CountRequest {
 	return &CountRequest{
 		Query: q,
@@ -29,45 +30,55 @@ func Count(q Mappable) *CountRequest {
 }
 
 // Map returns a map representation of the request, thus implementing the
-// Mappable interface.
+// Mappable interface. A request without a query counts all documents.
 func (req *CountRequest) Map() map[string]interface{} {
+	if req.Query == nil {
+		return map[string]interface{}{}
+	}
 	return map[string]interface{}{
 		"query": req.Query.Map(),
 	}
 }
 
-// Run executes the request using the provided OpenSearch client. It returns
-// the HTTP response directly for further processing.
+// CountResponse represents the response of a count request.
+type CountResponse struct {
+	Count  int64                        `json:"count"`
+	Shards opensearchapi.ResponseShards `json:"_shards"`
+}
+
+// Run executes the request using the provided OpenSearch client. The options'
+// Params, if provided, must be a *opensearchapi.IndicesCountParams, which
+// holds options such as routing, min_score and terminate_after.
 func (req *CountRequest) Run(
 	ctx context.Context,
 	client *opensearch.Client,
 	options *Options,
-) (*opensearchapi.SearchResp, error) {
+) (*CountResponse, error) {
 	// Serialize the request body to JSON
 	body, err := json.Marshal(req.Map())
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize request body: %w", err)
 	}
 
-	// Create a Search request, setting size to 0 to avoid fetching documents
-	searchReq := opensearchapi.SearchReq{
+	// Create a Count request
+	countReq := opensearchapi.IndicesCountReq{
 		Body: bytes.NewReader(body),
 	}
 
 	// Apply additional options if provided
-	err = ApplyOptions(&searchReq, options)
+	err = ApplyOptions(&countReq, options)
 	if err != nil {
 		return nil, err
 	}
 
 	// Create a variable to hold the response
-	var searchResp opensearchapi.SearchResp
+	var countResp CountResponse
 
-	// Execute the search request using the OpenSearch client's Do method
-	if _, err := client.Do(ctx, searchReq, &searchResp); err != nil {
-		return nil, fmt.Errorf("search request failed: %w", err)
+	// Execute the count request using the OpenSearch client
+	if err := execute(ctx, client, countReq, &countResp); err != nil {
+		return nil, fmt.Errorf("count request failed: %w", err)
 	}
 
 	// Return the parsed response
-	return &searchResp, nil
+	return &countResp, nil
 }
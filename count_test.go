@@ -4,7 +4,13 @@
 
 package osquery
 
-import "testing"
+import (
+	"context"
+	"testing"
+
+	"github.com/jgroeneveld/trial/assert"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
 
 func TestCount(t *testing.T) {
 	runMapTests(t, []mapTest{
@@ -17,5 +23,43 @@ func TestCount(t *testing.T) {
 				},
 			},
 		},
+		{
+			"a count request without a query",
+			Count(nil),
+			map[string]interface{}{},
+		},
+	})
+}
+
+func TestCountRun(t *testing.T) {
+	client, requests := newTestClient(t, func(int, recordedRequest) (int, string) {
+		return 200, `{"count":42,"_shards":{"total":2,"successful":2,"skipped":0,"failed":0}}`
 	})
+
+	minScore, terminateAfter := 1, 100
+	res, err := Count(Term("tag", "go")).Run(
+		context.Background(),
+		client,
+		&Options{
+			Indices: []string{"books"},
+			Params: &opensearchapi.IndicesCountParams{
+				Routing:        []string{"user1"},
+				MinScore:       &minScore,
+				TerminateAfter: &terminateAfter,
+			},
+		},
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(42), res.Count)
+	assert.Equal(t, 2, res.Shards.Successful)
+
+	reqs := requests()
+	assert.Equal(t, "/books/_count", reqs[0].Path)
+	assert.Equal(t, "min_score=1&routing=user1&terminate_after=100", reqs[0].Query)
+	assert.Equal(t, `{"query":{"term":{"tag":{"value":"go"}}}}`, reqs[0].Body)
+
+	_, err = Count(nil).Run(context.Background(), client, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "/_count", requests()[1].Path)
+	assert.Equal(t, `{}`, requests()[1].Body)
 }
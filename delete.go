@@ -16,10 +16,15 @@ import (
 
 // DeleteRequest represents a request to OpenSearch's Delete By Query API,
 // described in
-// https://opensearch.org/docs/latest/search-plugins/sql/sql/delete/
+// https://opensearch.org/docs/latest/api-reference/document-apis/delete-by-query/
 type DeleteRequest struct {
-	index []string
-	query Mappable
+	index             []string
+	query             Mappable
+	conflicts         Conflicts
+	maxDocs           *uint64
+	slices            interface{}
+	refresh           *bool
+	waitForCompletion *bool
 }
 
 // Delete creates a new DeleteRequest object, to be filled via method chaining.
@@ -27,7 +32,8 @@ func Delete() *DeleteRequest {
 	return &DeleteRequest{}
 }
 
-// Index sets the index names for the request
+// Index sets the index names for the request. They take precedence over the
+// indices provided via Options.
 func (req *DeleteRequest) Index(index ...string) *DeleteRequest {
 	req.index = index
 	return req
@@ -39,14 +45,78 @@ func (req *DeleteRequest) Query(q Mappable) *DeleteRequest {
 	return req
 }
 
-// Run executes the request using the provided OpenSearch client.
+// Conflicts sets how version conflicts are handled.
+func (req *DeleteRequest) Conflicts(conflicts Conflicts) *DeleteRequest {
+	req.conflicts = conflicts
+	return req
+}
+
+// MaxDocs sets the maximum number of documents to delete.
+func (req *DeleteRequest) MaxDocs(maxDocs uint64) *DeleteRequest {
+	req.maxDocs = &maxDocs
+	return req
+}
+
+// Slices sets the number of slices the request is divided into, to be
+// processed in parallel.
+func (req *DeleteRequest) Slices(slices uint) *DeleteRequest {
+	req.slices = int(slices)
+	return req
+}
+
+// SlicesAuto lets OpenSearch choose the number of slices the request is
+// divided into.
+func (req *DeleteRequest) SlicesAuto() *DeleteRequest {
+	req.slices = slicesAuto
+	return req
+}
+
+// Refresh sets whether the affected shards are refreshed once the request
+// completes.
+func (req *DeleteRequest) Refresh(b bool) *DeleteRequest {
+	req.refresh = &b
+	return req
+}
+
+// WaitForCompletion sets whether the request blocks until the operation
+// completes. When false, OpenSearch runs the operation as a task and the
+// response only holds the task's ID.
+func (req *DeleteRequest) WaitForCompletion(b bool) *DeleteRequest {
+	req.waitForCompletion = &b
+	return req
+}
+
+// Map returns a map representation of the request's body, thus implementing
+// the Mappable interface.
+func (req *DeleteRequest) Map() map[string]interface{} {
+	m := make(map[string]interface{})
+	if req.query != nil {
+		m["query"] = req.query.Map()
+	}
+	if req.conflicts != "" {
+		m["conflicts"] = req.conflicts
+	}
+	if req.maxDocs != nil {
+		m["max_docs"] = *req.maxDocs
+	}
+
+	return m
+}
+
+// Run executes the request using the provided OpenSearch client. The options'
+// Params, if provided, must be a *opensearchapi.DocumentDeleteByQueryParams;
+// values set on the request itself take precedence over them.
 func (req *DeleteRequest) Run(
 	ctx context.Context,
 	client *opensearch.Client,
 	options *Options,
-) (*opensearchapi.DocumentDeleteByQueryResp, error) {
+) (*BulkByScrollResponse, error) {
+	if req.query == nil {
+		return nil, fmt.Errorf("delete by query request requires a query")
+	}
+
 	// Serialize the request body to JSON
-	body, err := json.Marshal(req.query.Map())
+	body, err := json.Marshal(req.Map())
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize request body: %w", err)
 	}
@@ -62,10 +132,26 @@ func (req *DeleteRequest) Run(
 		return nil, err
 	}
 
-	var deleteResp opensearchapi.DocumentDeleteByQueryResp
+	if len(req.index) > 0 {
+		deleteReq.Indices = req.index
+	}
+	if len(deleteReq.Indices) == 0 {
+		return nil, fmt.Errorf("delete by query request requires at least one index")
+	}
+	if req.slices != nil {
+		deleteReq.Params.Slices = req.slices
+	}
+	if req.refresh != nil {
+		deleteReq.Params.Refresh = req.refresh
+	}
+	if req.waitForCompletion != nil {
+		deleteReq.Params.WaitForCompletion = req.waitForCompletion
+	}
+
+	var deleteResp BulkByScrollResponse
 
-	// Execute the delete request using the OpenSearch client's Do method
-	if _, err := client.Do(ctx, deleteReq, &deleteResp); err != nil {
+	// Execute the delete request using the OpenSearch client
+	if err := execute(ctx, client, deleteReq, &deleteResp); err != nil {
 		return nil, fmt.Errorf("delete request failed: %w", err)
 	}
 
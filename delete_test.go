@@ -0,0 +1,61 @@
+package osquery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jgroeneveld/trial/assert"
+)
+
+func TestDeleteMaps(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"delete by query",
+			Delete().
+				Query(Term("status", "spam")).
+				Conflicts(ConflictsProceed).
+				MaxDocs(50),
+			map[string]interface{}{
+				"query": map[string]interface{}{
+					"term": map[string]interface{}{
+						"status": map[string]interface{}{
+							"value": "spam",
+						},
+					},
+				},
+				"conflicts": "proceed",
+				"max_docs":  50,
+			},
+		},
+	})
+}
+
+func TestDeleteRun(t *testing.T) {
+	client, requests := newTestClient(t, func(int, recordedRequest) (int, string) {
+		return 200, `{"took":12,"timed_out":false,"total":3,"deleted":3,"batches":1,
+			"version_conflicts":0,"noops":0,"retries":{"bulk":0,"search":0},
+			"throttled_millis":0,"requests_per_second":-1.0,
+			"throttled_until_millis":0,"failures":[]}`
+	})
+	ctx := context.Background()
+
+	res, err := Delete().
+		Index("comments").
+		Query(Term("status", "spam")).
+		Slices(2).
+		Refresh(true).
+		Run(ctx, client, &Options{Indices: []string{"ignored"}})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, res.Deleted)
+
+	reqs := requests()
+	assert.Equal(t, "/comments/_delete_by_query", reqs[0].Path)
+	assert.Equal(t, "refresh=true&slices=2", reqs[0].Query)
+	assert.Equal(t, `{"query":{"term":{"status":{"value":"spam"}}}}`, reqs[0].Body)
+
+	_, err = Delete().Query(MatchAll()).Run(ctx, client, nil)
+	assert.NotNil(t, err)
+
+	_, err = Delete().Index("comments").Run(ctx, client, nil)
+	assert.NotNil(t, err)
+}
@@ -2,11 +2,36 @@
 // Changes: Added function score support
 package osquery
 
+// FunctionBoostMode is how the score computed by the functions is combined
+// with the score of the query.
+type FunctionBoostMode string
+
+const (
+	FunctionBoostModeMultiply FunctionBoostMode = "multiply"
+	FunctionBoostModeReplace  FunctionBoostMode = "replace"
+	FunctionBoostModeSum      FunctionBoostMode = "sum"
+	FunctionBoostModeAvg      FunctionBoostMode = "avg"
+	FunctionBoostModeMax      FunctionBoostMode = "max"
+	FunctionBoostModeMin      FunctionBoostMode = "min"
+)
+
+// FunctionScoreMode is how the scores of the functions are combined.
+type FunctionScoreMode string
+
+const (
+	FunctionScoreModeMultiply FunctionScoreMode = "multiply"
+	FunctionScoreModeSum      FunctionScoreMode = "sum"
+	FunctionScoreModeAvg      FunctionScoreMode = "avg"
+	FunctionScoreModeFirst    FunctionScoreMode = "first"
+	FunctionScoreModeMax      FunctionScoreMode = "max"
+	FunctionScoreModeMin      FunctionScoreMode = "min"
+)
+
 type FunctionScoreQuery struct {
 	query     Mappable
 	functions []Function
-	boostMode string
-	scoreMode string
+	boostMode FunctionBoostMode
+	scoreMode FunctionScoreMode
 	maxBoost  *float32
 	minScore  *float32
 	boost     *float32
@@ -16,20 +41,44 @@ type Function interface {
 	Map() map[string]interface{}
 }
 
+// functionParams holds the options every function accepts: a filter
+// restricting the documents the function applies to, and a weight its score
+// is multiplied by.
+type functionParams struct {
+	filter Mappable
+	weight *float32
+}
+
+// wrap returns the map of a function of the provided type and parameters,
+// along with its filter and weight.
+func (p *functionParams) wrap(name string, params map[string]interface{}) map[string]interface{} {
+	m := make(map[string]interface{})
+	if name != "" {
+		m[name] = params
+	}
+
+	if p.filter != nil {
+		m["filter"] = p.filter.Map()
+	}
+
+	if p.weight != nil {
+		m["weight"] = *p.weight
+	}
+
+	return m
+}
+
 type RandomScoreFunction struct {
+	functionParams
 	seed  *int64
 	field string
 }
 
 type ScriptScoreFunction struct {
+	functionParams
 	script *ScriptField
 }
 
-// Additional functions can be added as per usecase in future like:
-// - ScriptScoreFunction
-// - DecayFunction (with variants for geo, date, numeric)
-// - WeightFunction
-//
 // For ref: https://docs.opensearch.org/docs/latest/query-dsl/compound/function-score/
 
 func FunctionScore(query Mappable) *FunctionScoreQuery {
@@ -41,12 +90,12 @@ func (q *FunctionScoreQuery) Function(f Function) *FunctionScoreQuery {
 	return q
 }
 
-func (q *FunctionScoreQuery) BoostMode(boostMode string) *FunctionScoreQuery {
+func (q *FunctionScoreQuery) BoostMode(boostMode FunctionBoostMode) *FunctionScoreQuery {
 	q.boostMode = boostMode
 	return q
 }
 
-func (q *FunctionScoreQuery) ScoreMode(scoreMode string) *FunctionScoreQuery {
+func (q *FunctionScoreQuery) ScoreMode(scoreMode FunctionScoreMode) *FunctionScoreQuery {
 	q.scoreMode = scoreMode
 	return q
 }
@@ -120,6 +169,16 @@ func (f *RandomScoreFunction) Field(field string) *RandomScoreFunction {
 	return f
 }
 
+func (f *RandomScoreFunction) Filter(filter Mappable) *RandomScoreFunction {
+	f.filter = filter
+	return f
+}
+
+func (f *RandomScoreFunction) Weight(weight float32) *RandomScoreFunction {
+	f.weight = &weight
+	return f
+}
+
 func (f *RandomScoreFunction) Map() map[string]interface{} {
 	m := make(map[string]interface{})
 
@@ -131,25 +190,236 @@ func (f *RandomScoreFunction) Map() map[string]interface{} {
 		m["field"] = f.field
 	}
 
-	return map[string]interface{}{
-		"random_score": m,
-	}
+	return f.wrap("random_score", m)
 }
 
 func FunctionScriptScore(script *ScriptField) *ScriptScoreFunction {
 	return &ScriptScoreFunction{script: script}
 }
 
+func (f *ScriptScoreFunction) Filter(filter Mappable) *ScriptScoreFunction {
+	f.filter = filter
+	return f
+}
+
+func (f *ScriptScoreFunction) Weight(weight float32) *ScriptScoreFunction {
+	f.weight = &weight
+	return f
+}
+
 func (f *ScriptScoreFunction) Map() map[string]interface{} {
 	if f.script == nil {
-		return map[string]interface{}{
-			"script_score": map[string]interface{}{},
-		}
+		return f.wrap("script_score", map[string]interface{}{})
 	}
 	scriptMap := f.script.Map()["script"].(map[string]interface{})
-	return map[string]interface{}{
-		"script_score": map[string]interface{}{
-			"script": scriptMap,
-		},
+	return f.wrap("script_score", map[string]interface{}{
+		"script": scriptMap,
+	})
+}
+
+// WeightFunction multiplies the score by a constant weight, typically
+// combined with a filter to boost the documents matching it.
+type WeightFunction struct {
+	functionParams
+}
+
+// WeightScore creates a new standalone "weight" function.
+func WeightScore(weight float32) *WeightFunction {
+	f := &WeightFunction{}
+	f.weight = &weight
+	return f
+}
+
+func (f *WeightFunction) Filter(filter Mappable) *WeightFunction {
+	f.filter = filter
+	return f
+}
+
+func (f *WeightFunction) Map() map[string]interface{} {
+	return f.wrap("", nil)
+}
+
+// FieldValueFactorModifier is the modifier applied to the value of a
+// "field_value_factor" function.
+type FieldValueFactorModifier string
+
+const (
+	ModifierNone       FieldValueFactorModifier = "none"
+	ModifierLog        FieldValueFactorModifier = "log"
+	ModifierLog1p      FieldValueFactorModifier = "log1p"
+	ModifierLog2p      FieldValueFactorModifier = "log2p"
+	ModifierLn         FieldValueFactorModifier = "ln"
+	ModifierLn1p       FieldValueFactorModifier = "ln1p"
+	ModifierLn2p       FieldValueFactorModifier = "ln2p"
+	ModifierSquare     FieldValueFactorModifier = "square"
+	ModifierSqrt       FieldValueFactorModifier = "sqrt"
+	ModifierReciprocal FieldValueFactorModifier = "reciprocal"
+)
+
+// FieldValueFactorFunction scores documents using the value of a numeric
+// field, multiplied by a factor and passed through a modifier.
+type FieldValueFactorFunction struct {
+	functionParams
+	field    string
+	factor   *float32
+	modifier FieldValueFactorModifier
+	missing  *float64
+}
+
+// FieldValueFactor creates a new "field_value_factor" function on the
+// provided field.
+func FieldValueFactor(field string) *FieldValueFactorFunction {
+	return &FieldValueFactorFunction{field: field}
+}
+
+func (f *FieldValueFactorFunction) Factor(factor float32) *FieldValueFactorFunction {
+	f.factor = &factor
+	return f
+}
+
+func (f *FieldValueFactorFunction) Modifier(modifier FieldValueFactorModifier) *FieldValueFactorFunction {
+	f.modifier = modifier
+	return f
+}
+
+// Missing sets the value used for documents without a value for the field.
+func (f *FieldValueFactorFunction) Missing(missing float64) *FieldValueFactorFunction {
+	f.missing = &missing
+	return f
+}
+
+func (f *FieldValueFactorFunction) Filter(filter Mappable) *FieldValueFactorFunction {
+	f.filter = filter
+	return f
+}
+
+func (f *FieldValueFactorFunction) Weight(weight float32) *FieldValueFactorFunction {
+	f.weight = &weight
+	return f
+}
+
+func (f *FieldValueFactorFunction) Map() map[string]interface{} {
+	m := map[string]interface{}{
+		"field": f.field,
+	}
+
+	if f.factor != nil {
+		m["factor"] = *f.factor
+	}
+
+	if f.modifier != "" {
+		m["modifier"] = f.modifier
+	}
+
+	if f.missing != nil {
+		m["missing"] = *f.missing
+	}
+
+	return f.wrap("field_value_factor", m)
+}
+
+// DecayFunction scores documents by how far the value of a numeric, date or
+// geo field is from an origin, using a "gauss", "linear" or "exp" curve.
+// The origin and scale are numbers for numeric fields, dates and durations
+// (e.g. "now" and "10d") for date fields, and a GeoPoint (or *GeoPoint) and a
+// distance (e.g. "2km") for geo fields.
+type DecayFunction struct {
+	functionParams
+	decayType      string
+	field          string
+	origin         interface{}
+	scale          interface{}
+	offset         interface{}
+	decay          *float64
+	multiValueMode Mode
+}
+
+// GaussDecay creates a new "gauss" decay function.
+func GaussDecay(field string, origin, scale interface{}) *DecayFunction {
+	return newDecayFunction("gauss", field, origin, scale)
+}
+
+// LinearDecay creates a new "linear" decay function.
+func LinearDecay(field string, origin, scale interface{}) *DecayFunction {
+	return newDecayFunction("linear", field, origin, scale)
+}
+
+// ExpDecay creates a new "exp" decay function.
+func ExpDecay(field string, origin, scale interface{}) *DecayFunction {
+	return newDecayFunction("exp", field, origin, scale)
+}
+
+func newDecayFunction(decayType, field string, origin, scale interface{}) *DecayFunction {
+	return &DecayFunction{
+		decayType: decayType,
+		field:     field,
+		origin:    origin,
+		scale:     scale,
+	}
+}
+
+// Offset sets the distance from the origin under which documents are not
+// decayed.
+func (f *DecayFunction) Offset(offset interface{}) *DecayFunction {
+	f.offset = offset
+	return f
+}
+
+// Decay sets the score of documents at scale distance from the origin. The
+// default is 0.5.
+func (f *DecayFunction) Decay(decay float64) *DecayFunction {
+	f.decay = &decay
+	return f
+}
+
+// MultiValueMode sets which value is used when the field has several.
+func (f *DecayFunction) MultiValueMode(mode Mode) *DecayFunction {
+	f.multiValueMode = mode
+	return f
+}
+
+func (f *DecayFunction) Filter(filter Mappable) *DecayFunction {
+	f.filter = filter
+	return f
+}
+
+func (f *DecayFunction) Weight(weight float32) *DecayFunction {
+	f.weight = &weight
+	return f
+}
+
+func (f *DecayFunction) Map() map[string]interface{} {
+	fieldMap := map[string]interface{}{
+		"scale": f.scale,
+	}
+
+	switch origin := f.origin.(type) {
+	case nil:
+	case GeoPoint:
+		fieldMap["origin"] = origin.Map()
+	case *GeoPoint:
+		if origin != nil {
+			fieldMap["origin"] = origin.Map()
+		}
+	default:
+		fieldMap["origin"] = origin
+	}
+
+	if f.offset != nil {
+		fieldMap["offset"] = f.offset
 	}
+
+	if f.decay != nil {
+		fieldMap["decay"] = *f.decay
+	}
+
+	m := map[string]interface{}{
+		f.field: fieldMap,
+	}
+
+	if f.multiValueMode != "" {
+		m["multi_value_mode"] = f.multiValueMode
+	}
+
+	return f.wrap(f.decayType, m)
 }
@@ -231,3 +231,126 @@ func TestFunctionScoreWithQuery(t *testing.T) {
 		},
 	})
 }
+
+func TestFunctionScoreFunctions(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"function_score query with decay functions",
+			FunctionScore(MatchAll()).
+				Function(GaussDecay("price", 100, 20).Offset(5).Decay(0.3)).
+				Function(ExpDecay("published", "now", "10d").MultiValueMode(SortModeMax)).
+				Function(LinearDecay("location", LatLon(40.7, -73.9), "2km").Weight(3)).
+				ScoreMode(FunctionScoreModeSum).
+				BoostMode(FunctionBoostModeReplace),
+			map[string]interface{}{
+				"function_score": map[string]interface{}{
+					"query": map[string]interface{}{
+						"match_all": map[string]interface{}{},
+					},
+					"functions": []map[string]interface{}{
+						{
+							"gauss": map[string]interface{}{
+								"price": map[string]interface{}{
+									"origin": 100,
+									"scale":  20,
+									"offset": 5,
+									"decay":  0.3,
+								},
+							},
+						},
+						{
+							"exp": map[string]interface{}{
+								"published": map[string]interface{}{
+									"origin": "now",
+									"scale":  "10d",
+								},
+								"multi_value_mode": "max",
+							},
+						},
+						{
+							"linear": map[string]interface{}{
+								"location": map[string]interface{}{
+									"origin": map[string]interface{}{"lat": 40.7, "lon": -73.9},
+									"scale":  "2km",
+								},
+							},
+							"weight": 3,
+						},
+					},
+					"score_mode": "sum",
+					"boost_mode": "replace",
+				},
+			},
+		},
+		{
+			"function_score query with geo point pointer origin",
+			FunctionScore(MatchAll()).
+				Function(GaussDecay("location", &GeoPoint{Lat: 40.7, Lon: -73.9}, "2km")),
+			map[string]interface{}{
+				"function_score": map[string]interface{}{
+					"query": map[string]interface{}{
+						"match_all": map[string]interface{}{},
+					},
+					"functions": []map[string]interface{}{
+						{
+							"gauss": map[string]interface{}{
+								"location": map[string]interface{}{
+									"origin": map[string]interface{}{"lat": 40.7, "lon": -73.9},
+									"scale":  "2km",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"function_score query with field_value_factor and weight functions",
+			FunctionScore(Match("title").Query("go")).
+				Function(FieldValueFactor("likes").Factor(1.2).Modifier(ModifierSqrt).Missing(1)).
+				Function(WeightScore(2).Filter(Term("tag", "featured"))).
+				Function(RandomScore().Seed(10).Field("_seq_no").Filter(Term("tag", "new")).Weight(0.5)),
+			map[string]interface{}{
+				"function_score": map[string]interface{}{
+					"query": map[string]interface{}{
+						"match": map[string]interface{}{
+							"title": map[string]interface{}{
+								"query": "go",
+							},
+						},
+					},
+					"functions": []map[string]interface{}{
+						{
+							"field_value_factor": map[string]interface{}{
+								"field":    "likes",
+								"factor":   float32(1.2),
+								"modifier": "sqrt",
+								"missing":  1,
+							},
+						},
+						{
+							"filter": map[string]interface{}{
+								"term": map[string]interface{}{
+									"tag": map[string]interface{}{"value": "featured"},
+								},
+							},
+							"weight": 2,
+						},
+						{
+							"random_score": map[string]interface{}{
+								"seed":  10,
+								"field": "_seq_no",
+							},
+							"filter": map[string]interface{}{
+								"term": map[string]interface{}{
+									"tag": map[string]interface{}{"value": "new"},
+								},
+							},
+							"weight": 0.5,
+						},
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,144 @@
+package osquery
+
+// InnerHitsOptions represents the "inner_hits" option of the Nested,
+// HasChild and HasParent queries and of field collapsing, as described in
+// https://opensearch.org/docs/latest/search-plugins/searching-data/inner-hits/
+// It returns, along with each hit, the nested objects, children, parent or
+// collapsed documents that caused it to match. Inner hits are returned in
+// SearchHit.InnerHits, keyed by name, and can be decoded with
+// DecodeInnerHits.
+type InnerHitsOptions struct {
+	name           string
+	from           *uint64
+	size           *uint64
+	sort           []SortOption
+	source         Source
+	highlight      Mappable
+	docvalueFields []string
+	scriptFields   []*ScriptField
+	explain        *bool
+}
+
+// InnerHits creates a new InnerHitsOptions object, to be filled via method
+// chaining.
+func InnerHits() *InnerHitsOptions {
+	return &InnerHitsOptions{}
+}
+
+// Name sets the key of the inner hits in the response. It defaults to the
+// path of a Nested query, the relation name of a HasChild or HasParent
+// query, or the collapsed field. It is required to return several inner hits
+// from a single collapse.
+func (ih *InnerHitsOptions) Name(name string) *InnerHitsOptions {
+	ih.name = name
+	return ih
+}
+
+// From sets the offset of the first inner hit to return.
+func (ih *InnerHitsOptions) From(offset uint64) *InnerHitsOptions {
+	ih.from = &offset
+	return ih
+}
+
+// Size sets the maximum number of inner hits to return. The default is 3.
+func (ih *InnerHitsOptions) Size(size uint64) *InnerHitsOptions {
+	ih.size = &size
+	return ih
+}
+
+// Sort appends one or more sort options for the inner hits.
+func (ih *InnerHitsOptions) Sort(opts ...SortOption) *InnerHitsOptions {
+	ih.sort = append(ih.sort, opts...)
+	return ih
+}
+
+// SourceIncludes sets the keys to return from the inner hits.
+func (ih *InnerHitsOptions) SourceIncludes(keys ...string) *InnerHitsOptions {
+	ih.source.includes = keys
+	return ih
+}
+
+// SourceExcludes sets the keys to not return from the inner hits.
+func (ih *InnerHitsOptions) SourceExcludes(keys ...string) *InnerHitsOptions {
+	ih.source.excludes = keys
+	return ih
+}
+
+// Highlight sets a highlight for the inner hits.
+func (ih *InnerHitsOptions) Highlight(highlight *QueryHighlight) *InnerHitsOptions {
+	ih.highlight = highlight
+	return ih
+}
+
+// DocvalueFields sets fields whose doc values are returned with each inner
+// hit.
+func (ih *InnerHitsOptions) DocvalueFields(fields ...string) *InnerHitsOptions {
+	ih.docvalueFields = append(ih.docvalueFields, fields...)
+	return ih
+}
+
+// ScriptFields sets fields computed by scripts for each inner hit.
+func (ih *InnerHitsOptions) ScriptFields(fields ...*ScriptField) *InnerHitsOptions {
+	ih.scriptFields = append(ih.scriptFields, fields...)
+	return ih
+}
+
+// Explain sets whether an explanation of the score of each inner hit is
+// returned.
+func (ih *InnerHitsOptions) Explain(b bool) *InnerHitsOptions {
+	ih.explain = &b
+	return ih
+}
+
+// Map returns a map representation of the inner hits options, thus
+// implementing the Mappable interface.
+func (ih *InnerHitsOptions) Map() map[string]interface{} {
+	m := make(map[string]interface{})
+	if ih.name != "" {
+		m["name"] = ih.name
+	}
+	if ih.from != nil {
+		m["from"] = *ih.from
+	}
+	if ih.size != nil {
+		m["size"] = *ih.size
+	}
+	if len(ih.sort) > 0 {
+		sortSlice := make([]any, 0, len(ih.sort))
+		for _, params := range ih.sort {
+			sortSlice = append(sortSlice, params.Map())
+		}
+		m["sort"] = sortSlice
+	}
+	source := ih.source.Map()
+	if len(source) > 0 {
+		m["_source"] = source
+	}
+	if ih.highlight != nil {
+		m["highlight"] = ih.highlight.Map()
+	}
+	if len(ih.docvalueFields) > 0 {
+		m["docvalue_fields"] = ih.docvalueFields
+	}
+	if len(ih.scriptFields) > 0 {
+		scripts := make(map[string]interface{})
+		for _, script := range ih.scriptFields {
+			scripts[script.Name()] = script.Map()
+		}
+		m["script_fields"] = scripts
+	}
+	if ih.explain != nil {
+		m["explain"] = *ih.explain
+	}
+
+	return m
+}
+
+// innerHitsMap returns the map of the provided inner hits options, or nil if
+// they are not set.
+func innerHitsMap(ih *InnerHitsOptions) map[string]interface{} {
+	if ih == nil {
+		return nil
+	}
+	return ih.Map()
+}
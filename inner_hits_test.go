@@ -0,0 +1,65 @@
+package osquery
+
+import (
+	"testing"
+)
+
+func TestInnerHits(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"inner_hits with all options",
+			InnerHits().
+				Name("top_comments").
+				From(1).
+				Size(5).
+				Sort(FieldSort("comments.date").Order(OrderDesc)).
+				SourceIncludes("comments.text").
+				SourceExcludes("comments.raw").
+				Highlight(Highlight().Field("comments.text")).
+				DocvalueFields("comments.votes").
+				ScriptFields(Script("double_votes").Source("doc['comments.votes'].value * 2")).
+				Explain(true),
+			map[string]interface{}{
+				"name": "top_comments",
+				"from": 1,
+				"size": 5,
+				"sort": []map[string]interface{}{
+					{"comments.date": map[string]interface{}{"order": "desc"}},
+				},
+				"_source": map[string]interface{}{
+					"includes": []string{"comments.text"},
+					"excludes": []string{"comments.raw"},
+				},
+				"highlight": map[string]interface{}{
+					"fields": map[string]interface{}{
+						"comments.text": map[string]interface{}{},
+					},
+				},
+				"docvalue_fields": []string{"comments.votes"},
+				"script_fields": map[string]interface{}{
+					"double_votes": map[string]interface{}{
+						"script": map[string]interface{}{
+							"source": "doc['comments.votes'].value * 2",
+						},
+					},
+				},
+				"explain": true,
+			},
+		},
+		{
+			"inner_hits in a joining query",
+			HasParent("order", MatchAll()).InnerHits(InnerHits().SourceIncludes("status")),
+			map[string]interface{}{
+				"has_parent": map[string]interface{}{
+					"parent_type": "order",
+					"query":       map[string]interface{}{"match_all": map[string]interface{}{}},
+					"inner_hits": map[string]interface{}{
+						"_source": map[string]interface{}{
+							"includes": []string{"status"},
+						},
+					},
+				},
+			},
+		},
+	})
+}
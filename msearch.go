@@ -0,0 +1,192 @@
+package osquery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// MultiSearchRequest represents a request to OpenSearch's Multi-search API,
+// executing several search requests in a single round-trip, as described in:
+// https://opensearch.org/docs/latest/api-reference/multi-search/
+type MultiSearchRequest struct {
+	searches []multiSearchItem
+}
+
+type multiSearchItem struct {
+	req     *SearchRequest
+	options *Options
+}
+
+// MSearch creates a new MultiSearchRequest object, to be filled via method
+// chaining.
+func MSearch() *MultiSearchRequest {
+	return &MultiSearchRequest{}
+}
+
+// Add appends a search request to the multi-search. The options, which may be
+// nil, are used to build the search's header line: Indices sets the indices
+// to search, and Params may be a *opensearchapi.SearchParams whose routing,
+// preference, search_type, request_cache and index-resolution parameters are
+// applied to this search only. Options.Header is ignored, as HTTP headers can
+// only be set for the whole multi-search, via the options given to Run.
+func (m *MultiSearchRequest) Add(req *SearchRequest, options *Options) *MultiSearchRequest {
+	m.searches = append(m.searches, multiSearchItem{
+		req:     req,
+		options: options,
+	})
+	return m
+}
+
+// Body serializes the multi-search into its NDJSON body, made of a header and
+// a body line per search request.
+func (m *MultiSearchRequest) Body() ([]byte, error) {
+	var buf bytes.Buffer
+	for i, item := range m.searches {
+		if err := ValidateBucketsPaths(item.req.aggs...); err != nil {
+			return nil, fmt.Errorf("search %d: %w", i, err)
+		}
+		header, err := item.header()
+		if err != nil {
+			return nil, fmt.Errorf("search %d: %w", i, err)
+		}
+		if err := writeNDJSON(&buf, header); err != nil {
+			return nil, fmt.Errorf("search %d: failed to serialize header: %w", i, err)
+		}
+		if err := writeNDJSON(&buf, item.req.Map()); err != nil {
+			return nil, fmt.Errorf("search %d: failed to serialize request body: %w", i, err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// header returns the header line of the search.
+func (item multiSearchItem) header() (map[string]interface{}, error) {
+	header := make(map[string]interface{})
+	if item.options == nil {
+		return header, nil
+	}
+
+	// A point in time already determines the indices to search
+	if len(item.options.Indices) > 0 && item.req.pit == nil {
+		header["index"] = strings.Join(item.options.Indices, ",")
+	}
+
+	if item.options.Params == nil {
+		return header, nil
+	}
+	params, ok := item.options.Params.(*opensearchapi.SearchParams)
+	if !ok {
+		return nil, fmt.Errorf("invalid type for SearchParams")
+	}
+
+	if len(params.Routing) > 0 {
+		header["routing"] = strings.Join(params.Routing, ",")
+	}
+	if params.Preference != "" {
+		header["preference"] = params.Preference
+	}
+	if params.SearchType != "" {
+		header["search_type"] = params.SearchType
+	}
+	if params.RequestCache != nil {
+		header["request_cache"] = *params.RequestCache
+	}
+	if params.AllowNoIndices != nil {
+		header["allow_no_indices"] = *params.AllowNoIndices
+	}
+	if params.IgnoreUnavailable != nil {
+		header["ignore_unavailable"] = *params.IgnoreUnavailable
+	}
+	if params.ExpandWildcards != "" {
+		header["expand_wildcards"] = params.ExpandWildcards
+	}
+	if params.AllowPartialSearchResults != nil {
+		header["allow_partial_search_results"] = *params.AllowPartialSearchResults
+	}
+	if params.CcsMinimizeRoundtrips != nil {
+		header["ccs_minimize_roundtrips"] = *params.CcsMinimizeRoundtrips
+	}
+
+	return header, nil
+}
+
+// writeNDJSON writes v to buf as a single line of JSON.
+func writeNDJSON(buf *bytes.Buffer, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(b)
+	buf.WriteByte('\n')
+	return nil
+}
+
+// MultiSearchResult represents the response of a multi-search request.
+// Responses holds the response of every search, in the order the searches
+// were added.
+type MultiSearchResult struct {
+	Took      int                   `json:"took"`
+	Responses []MultiSearchResponse `json:"responses"`
+}
+
+// MultiSearchResponse is the response of a single search of a multi-search.
+// If the search failed, Error describes why, and Err returns it as an error.
+// Hits can be decoded into a concrete type using DecodeSearchHits.
+type MultiSearchResponse struct {
+	SearchResult[json.RawMessage]
+	Status int         `json:"status"`
+	Error  *ErrorCause `json:"error,omitempty"`
+}
+
+// Err returns the error of the search, or nil if it succeeded.
+func (res *MultiSearchResponse) Err() error {
+	if res.Error == nil {
+		return nil
+	}
+	return &ResponseError{
+		StatusCode: res.Status,
+		Cause:      res.Error,
+	}
+}
+
+// Run executes the multi-search using the provided OpenSearch client. The
+// options apply to the whole request: Indices are the default indices for
+// searches that don't specify any, and Params may be a
+// *opensearchapi.MSearchParams.
+func (m *MultiSearchRequest) Run(
+	ctx context.Context,
+	client *opensearch.Client,
+	options *Options,
+) (*MultiSearchResult, error) {
+	if len(m.searches) == 0 {
+		return nil, fmt.Errorf("multi-search request has no searches")
+	}
+
+	body, err := m.Body()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize request body: %w", err)
+	}
+
+	msearchReq := opensearchapi.MSearchReq{
+		Body: bytes.NewReader(body),
+	}
+
+	// Apply additional options if provided
+	err = ApplyOptions(&msearchReq, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var msearchResp MultiSearchResult
+	if err := execute(ctx, client, msearchReq, &msearchResp); err != nil {
+		return nil, fmt.Errorf("multi-search request failed: %w", err)
+	}
+
+	return &msearchResp, nil
+}
@@ -0,0 +1,74 @@
+package osquery
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jgroeneveld/trial/assert"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+func TestMultiSearchBody(t *testing.T) {
+	body, err := MSearch().
+		Add(Search().Query(Term("tag", "go")).Size(5), &Options{
+			Indices: []string{"books", "articles"},
+			Params: &opensearchapi.SearchParams{
+				Routing:      []string{"user1"},
+				Preference:   "_local",
+				RequestCache: opensearchapi.ToPointer(true),
+			},
+		}).
+		Add(Aggregate(Avg("avg_price", "price")), nil).
+		Body()
+	assert.Nil(t, err)
+
+	assert.Equal(t, ``+
+		`{"index":"books,articles","preference":"_local","request_cache":true,"routing":"user1"}`+"\n"+
+		`{"query":{"term":{"tag":{"value":"go"}}},"size":5}`+"\n"+
+		`{}`+"\n"+
+		`{"aggs":{"avg_price":{"avg":{"field":"price"}}}}`+"\n",
+		string(body),
+	)
+
+	_, err = MSearch().
+		Add(Search(), &Options{Params: &opensearchapi.MSearchParams{}}).
+		Body()
+	assert.NotNil(t, err)
+}
+
+func TestMultiSearchRun(t *testing.T) {
+	client, requests := newTestClient(t, func(int, recordedRequest) (int, string) {
+		return 200, `{"took":3,"responses":[
+			{"took":1,"status":200,"hits":{"total":{"value":1,"relation":"eq"},"hits":[
+				{"_index":"books","_id":"1","_score":1.0,"_source":{"n":7}}
+			]}},
+			{"status":404,"error":{"type":"index_not_found_exception","reason":"no such index [nope]"}}
+		]}`
+	})
+
+	res, err := MSearch().
+		Add(Search().Query(MatchAll()), &Options{Indices: []string{"books"}}).
+		Add(Search().Query(MatchAll()), &Options{Indices: []string{"nope"}}).
+		Run(context.Background(), client, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(res.Responses))
+
+	assert.Nil(t, res.Responses[0].Err())
+	hits, err := DecodeSearchHits[iteratorDoc](res.Responses[0].Hits)
+	assert.Nil(t, err)
+	assert.Equal(t, 7, hits.Hits[0].Source.N)
+
+	resErr, ok := res.Responses[1].Err().(*ResponseError)
+	assert.True(t, ok)
+	assert.Equal(t, 404, resErr.StatusCode)
+	assert.Equal(t, "index_not_found_exception", resErr.Cause.Type)
+
+	reqs := requests()
+	assert.Equal(t, "/_msearch", reqs[0].Path)
+
+	lines := strings.Split(reqs[0].Body, "\n")
+	assert.Equal(t, 5, len(lines))
+	assert.Equal(t, `{"index":"books"}`, lines[0])
+	assert.Equal(t, `{"index":"nope"}`, lines[2])
+}
@@ -49,6 +49,101 @@ func ApplyOptions(req interface{}, options *Options) error {
 			}
 			r.Params = *params
 		}
+	case *opensearchapi.PointInTimeCreateReq:
+		if options.Indices != nil {
+			r.Indices = options.Indices
+		}
+		if options.Header != nil {
+			r.Header = options.Header
+		}
+		if options.Params != nil {
+			params, ok := options.Params.(*opensearchapi.PointInTimeCreateParams)
+			if !ok {
+				return fmt.Errorf("invalid type for PointInTimeCreateParams")
+			}
+			r.Params = *params
+		}
+	case *opensearchapi.PointInTimeDeleteReq:
+		if options.Header != nil {
+			r.Header = options.Header
+		}
+		if options.Params != nil {
+			params, ok := options.Params.(*opensearchapi.PointInTimeDeleteParams)
+			if !ok {
+				return fmt.Errorf("invalid type for PointInTimeDeleteParams")
+			}
+			r.Params = *params
+		}
+	case *opensearchapi.MSearchReq:
+		if options.Indices != nil {
+			r.Indices = options.Indices
+		}
+		if options.Header != nil {
+			r.Header = options.Header
+		}
+		if options.Params != nil {
+			params, ok := options.Params.(*opensearchapi.MSearchParams)
+			if !ok {
+				return fmt.Errorf("invalid type for MSearchParams")
+			}
+			r.Params = *params
+		}
+	case *opensearchapi.BulkReq:
+		if len(options.Indices) > 1 {
+			return fmt.Errorf("bulk requests accept a single default index")
+		}
+		if len(options.Indices) == 1 {
+			r.Index = options.Indices[0]
+		}
+		if options.Header != nil {
+			r.Header = options.Header
+		}
+		if options.Params != nil {
+			params, ok := options.Params.(*opensearchapi.BulkParams)
+			if !ok {
+				return fmt.Errorf("invalid type for BulkParams")
+			}
+			r.Params = *params
+		}
+	case *opensearchapi.UpdateByQueryReq:
+		if options.Indices != nil {
+			r.Indices = options.Indices
+		}
+		if options.Header != nil {
+			r.Header = options.Header
+		}
+		if options.Params != nil {
+			params, ok := options.Params.(*opensearchapi.UpdateByQueryParams)
+			if !ok {
+				return fmt.Errorf("invalid type for UpdateByQueryParams")
+			}
+			r.Params = *params
+		}
+	case *opensearchapi.ReindexReq:
+		if options.Header != nil {
+			r.Header = options.Header
+		}
+		if options.Params != nil {
+			params, ok := options.Params.(*opensearchapi.ReindexParams)
+			if !ok {
+				return fmt.Errorf("invalid type for ReindexParams")
+			}
+			r.Params = *params
+		}
+	case *opensearchapi.IndicesCountReq:
+		if options.Indices != nil {
+			r.Indices = options.Indices
+		}
+		if options.Header != nil {
+			r.Header = options.Header
+		}
+		if options.Params != nil {
+			params, ok := options.Params.(*opensearchapi.IndicesCountParams)
+			if !ok {
+				return fmt.Errorf("invalid type for IndicesCountParams")
+			}
+			r.Params = *params
+		}
 	// Add more cases for other request types as needed
 	default:
 		return fmt.Errorf("unsupported request type: %T", req)
@@ -0,0 +1,133 @@
+package osquery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// CreatePITRequest represents a request to create a point in time (PIT), as
+// described in:
+// https://opensearch.org/docs/latest/search-plugins/searching-data/point-in-time-api/
+type CreatePITRequest struct {
+	indices      []string
+	keepAlive    time.Duration
+	routing      string
+	preference   string
+	allowPartial bool
+}
+
+// CreatePIT creates a new CreatePITRequest for the provided indices, to be
+// filled via method chaining.
+func CreatePIT(indices ...string) *CreatePITRequest {
+	return &CreatePITRequest{
+		indices: indices,
+	}
+}
+
+// KeepAlive sets for how long the point in time is kept. It is required by
+// OpenSearch.
+func (req *CreatePITRequest) KeepAlive(keepAlive time.Duration) *CreatePITRequest {
+	req.keepAlive = keepAlive
+	return req
+}
+
+// Routing sets the routing value used to route the requests to shards.
+func (req *CreatePITRequest) Routing(routing string) *CreatePITRequest {
+	req.routing = routing
+	return req
+}
+
+// Preference sets the node or shard used to perform the search.
+func (req *CreatePITRequest) Preference(preference string) *CreatePITRequest {
+	req.preference = preference
+	return req
+}
+
+// AllowPartialPitCreation sets whether a point in time may be created with
+// partial failures.
+func (req *CreatePITRequest) AllowPartialPitCreation(b bool) *CreatePITRequest {
+	req.allowPartial = b
+	return req
+}
+
+// Run executes the request using the provided OpenSearch client.
+func (req *CreatePITRequest) Run(
+	ctx context.Context,
+	client *opensearch.Client,
+	options *Options,
+) (*opensearchapi.PointInTimeCreateResp, error) {
+	pitReq := opensearchapi.PointInTimeCreateReq{}
+
+	// Apply additional options if provided
+	err := ApplyOptions(&pitReq, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.indices) > 0 {
+		pitReq.Indices = req.indices
+	}
+	if req.keepAlive > 0 {
+		pitReq.Params.KeepAlive = req.keepAlive
+	}
+	if req.routing != "" {
+		pitReq.Params.Routing = req.routing
+	}
+	if req.preference != "" {
+		pitReq.Params.Preference = req.preference
+	}
+	if req.allowPartial {
+		pitReq.Params.AllowPartialPitCreation = true
+	}
+
+	var pitResp opensearchapi.PointInTimeCreateResp
+	if err := execute(ctx, client, pitReq, &pitResp); err != nil {
+		return nil, fmt.Errorf("create point in time request failed: %w", err)
+	}
+
+	return &pitResp, nil
+}
+
+//----------------------------------------------------------------------------//
+
+// DeletePITRequest represents a request to delete one or more points in time,
+// as described in:
+// https://opensearch.org/docs/latest/search-plugins/searching-data/point-in-time-api/
+type DeletePITRequest struct {
+	ids []string
+}
+
+// DeletePIT creates a new DeletePITRequest for the provided point in time IDs.
+func DeletePIT(ids ...string) *DeletePITRequest {
+	return &DeletePITRequest{
+		ids: ids,
+	}
+}
+
+// Run executes the request using the provided OpenSearch client.
+func (req *DeletePITRequest) Run(
+	ctx context.Context,
+	client *opensearch.Client,
+	options *Options,
+) (*opensearchapi.PointInTimeDeleteResp, error) {
+	pitReq := opensearchapi.PointInTimeDeleteReq{
+		PitID: req.ids,
+	}
+
+	// Apply additional options if provided
+	err := ApplyOptions(&pitReq, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var pitResp opensearchapi.PointInTimeDeleteResp
+	if err := execute(ctx, client, pitReq, &pitResp); err != nil {
+		return nil, fmt.Errorf("delete point in time request failed: %w", err)
+	}
+
+	return &pitResp, nil
+}
@@ -0,0 +1,48 @@
+package osquery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jgroeneveld/trial/assert"
+)
+
+func TestPointInTime(t *testing.T) {
+	client, requests := newTestClient(t, func(_ int, req recordedRequest) (int, string) {
+		switch req.Path {
+		case "/books/_search/point_in_time":
+			return 200, `{"pit_id":"pit-1","_shards":{"total":1,"successful":1},"creation_time":1}`
+		case "/_search":
+			return 200, `{"pit_id":"pit-2","hits":{"hits":[]}}`
+		default:
+			return 200, `{"pits":[{"pit_id":"pit-2","successful":true}]}`
+		}
+	})
+	ctx := context.Background()
+
+	pit, err := CreatePIT("books").KeepAlive(time.Minute).Run(ctx, client, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "pit-1", pit.PitID)
+
+	it := Iterate[iteratorDoc](
+		Search().PointInTime(pit.PitID, time.Minute),
+		client,
+		&Options{Indices: []string{"books"}},
+	)
+	assert.False(t, it.Next(ctx))
+	assert.Nil(t, it.Err())
+	assert.Equal(t, "pit-2", it.req.pit.id)
+
+	del, err := DeletePIT(it.req.pit.id).Run(ctx, client, nil)
+	assert.Nil(t, err)
+	assert.True(t, del.Pits[0].Successful)
+
+	reqs := requests()
+	assert.Equal(t, 3, len(reqs))
+	assert.Equal(t, "keep_alive=60000ms", reqs[0].Query)
+	// the index must not be sent along with the point in time
+	assert.Equal(t, "/_search", reqs[1].Path)
+	assert.Equal(t, "DELETE", reqs[2].Method)
+	assert.Equal(t, `{"pit_id":["pit-2"]}`, reqs[2].Body)
+}
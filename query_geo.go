@@ -0,0 +1,431 @@
+package osquery
+
+// GeoPoint represents a geographical point, as accepted by "geo_point" fields.
+// It serializes to an object with "lat" and "lon" properties.
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// LatLon creates a new GeoPoint from the provided latitude and longitude.
+func LatLon(lat, lon float64) GeoPoint {
+	return GeoPoint{Lat: lat, Lon: lon}
+}
+
+// Map returns a map representation of the point, thus implementing the
+// Mappable interface.
+func (p GeoPoint) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"lat": p.Lat,
+		"lon": p.Lon,
+	}
+}
+
+// coordinates returns the point as a GeoJSON position, which lists the
+// longitude first.
+func (p GeoPoint) coordinates() []float64 {
+	return []float64{p.Lon, p.Lat}
+}
+
+func geoPointsMap(points []GeoPoint) []map[string]interface{} {
+	maps := make([]map[string]interface{}, len(points))
+	for i, p := range points {
+		maps[i] = p.Map()
+	}
+	return maps
+}
+
+func geoPositions(points []GeoPoint) [][]float64 {
+	positions := make([][]float64, len(points))
+	for i, p := range points {
+		positions[i] = p.coordinates()
+	}
+	return positions
+}
+
+// Shape represents a geographical shape, as accepted by "geo_shape" fields
+// and queries. It serializes to GeoJSON, except for envelopes which use
+// OpenSearch's own format.
+type Shape struct {
+	shapeType   string
+	coordinates interface{}
+}
+
+// PointShape creates a shape of type "point".
+func PointShape(p GeoPoint) *Shape {
+	return &Shape{shapeType: "point", coordinates: p.coordinates()}
+}
+
+// MultiPointShape creates a shape of type "multipoint".
+func MultiPointShape(points ...GeoPoint) *Shape {
+	return &Shape{shapeType: "multipoint", coordinates: geoPositions(points)}
+}
+
+// LineStringShape creates a shape of type "linestring" going through the
+// provided points.
+func LineStringShape(points ...GeoPoint) *Shape {
+	return &Shape{shapeType: "linestring", coordinates: geoPositions(points)}
+}
+
+// PolygonShape creates a shape of type "polygon". The first ring is the outer
+// boundary of the polygon, and any additional ring is a hole in it. Each ring
+// must be closed, i.e. its first and last points must be the same.
+func PolygonShape(rings ...[]GeoPoint) *Shape {
+	coordinates := make([][][]float64, len(rings))
+	for i, ring := range rings {
+		coordinates[i] = geoPositions(ring)
+	}
+	return &Shape{shapeType: "polygon", coordinates: coordinates}
+}
+
+// EnvelopeShape creates a shape of type "envelope", i.e. the rectangle with
+// the provided top left and bottom right corners.
+func EnvelopeShape(topLeft, bottomRight GeoPoint) *Shape {
+	return &Shape{
+		shapeType:   "envelope",
+		coordinates: [][]float64{topLeft.coordinates(), bottomRight.coordinates()},
+	}
+}
+
+// Map returns a map representation of the shape, thus implementing the
+// Mappable interface.
+func (s *Shape) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        s.shapeType,
+		"coordinates": s.coordinates,
+	}
+}
+
+// GeoValidationMethod is an enumeration type for the "validation_method" field
+// of geo queries.
+type GeoValidationMethod string
+
+const (
+	// GeoValidationStrict rejects queries with invalid coordinates.
+	GeoValidationStrict GeoValidationMethod = "STRICT"
+
+	// GeoValidationIgnoreMalformed accepts invalid coordinates.
+	GeoValidationIgnoreMalformed GeoValidationMethod = "IGNORE_MALFORMED"
+
+	// GeoValidationCoerce tries to convert invalid coordinates into valid
+	// ones.
+	GeoValidationCoerce GeoValidationMethod = "COERCE"
+)
+
+// GeoDistanceType is an enumeration type for the way distances are computed.
+type GeoDistanceType string
+
+const (
+	// GeoDistanceArc computes distances on a sphere. It is the default.
+	GeoDistanceArc GeoDistanceType = "arc"
+
+	// GeoDistancePlane computes distances on a plane, which is faster but
+	// inaccurate over long distances and near the poles.
+	GeoDistancePlane GeoDistanceType = "plane"
+)
+
+// geoQueryParams holds the options shared by the geo_point queries.
+type geoQueryParams struct {
+	validationMethod GeoValidationMethod
+	ignoreUnmapped   *bool
+	boost            *float32
+}
+
+func (params *geoQueryParams) apply(innerMap map[string]interface{}) {
+	if params.validationMethod != "" {
+		innerMap["validation_method"] = params.validationMethod
+	}
+	if params.ignoreUnmapped != nil {
+		innerMap["ignore_unmapped"] = *params.ignoreUnmapped
+	}
+	if params.boost != nil {
+		innerMap["boost"] = *params.boost
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// GeoDistanceQuery represents a query of type "geo_distance", as described in:
+// https://opensearch.org/docs/latest/query-dsl/geo-and-xy/geodistance/
+type GeoDistanceQuery struct {
+	field        string
+	point        GeoPoint
+	distance     string
+	distanceType GeoDistanceType
+	params       geoQueryParams
+}
+
+// GeoDistance creates a new query of type "geo_distance", matching documents
+// whose point in the provided field is within distance (e.g. "12km") of the
+// provided point.
+func GeoDistance(field string, point GeoPoint, distance string) *GeoDistanceQuery {
+	return &GeoDistanceQuery{
+		field:    field,
+		point:    point,
+		distance: distance,
+	}
+}
+
+// DistanceType sets how distances are computed.
+func (q *GeoDistanceQuery) DistanceType(t GeoDistanceType) *GeoDistanceQuery {
+	q.distanceType = t
+	return q
+}
+
+// ValidationMethod sets how invalid coordinates are handled.
+func (q *GeoDistanceQuery) ValidationMethod(m GeoValidationMethod) *GeoDistanceQuery {
+	q.params.validationMethod = m
+	return q
+}
+
+// IgnoreUnmapped sets whether to match no documents, rather than fail, if the
+// field is not mapped.
+func (q *GeoDistanceQuery) IgnoreUnmapped(b bool) *GeoDistanceQuery {
+	q.params.ignoreUnmapped = &b
+	return q
+}
+
+// Boost sets the boost value of the query.
+func (q *GeoDistanceQuery) Boost(b float32) *GeoDistanceQuery {
+	q.params.boost = &b
+	return q
+}
+
+// Map returns a map representation of the query, thus implementing the
+// Mappable interface.
+func (q *GeoDistanceQuery) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"distance": q.distance,
+		q.field:    q.point.Map(),
+	}
+	if q.distanceType != "" {
+		innerMap["distance_type"] = q.distanceType
+	}
+	q.params.apply(innerMap)
+
+	return map[string]interface{}{
+		"geo_distance": innerMap,
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// GeoBoundingBoxQuery represents a query of type "geo_bounding_box", as
+// described in:
+// https://opensearch.org/docs/latest/query-dsl/geo-and-xy/geo-bounding-box/
+type GeoBoundingBoxQuery struct {
+	field       string
+	topLeft     GeoPoint
+	bottomRight GeoPoint
+	params      geoQueryParams
+}
+
+// GeoBoundingBox creates a new query of type "geo_bounding_box", matching
+// documents whose point in the provided field is within the rectangle with
+// the provided top left and bottom right corners.
+func GeoBoundingBox(field string, topLeft, bottomRight GeoPoint) *GeoBoundingBoxQuery {
+	return &GeoBoundingBoxQuery{
+		field:       field,
+		topLeft:     topLeft,
+		bottomRight: bottomRight,
+	}
+}
+
+// ValidationMethod sets how invalid coordinates are handled.
+func (q *GeoBoundingBoxQuery) ValidationMethod(m GeoValidationMethod) *GeoBoundingBoxQuery {
+	q.params.validationMethod = m
+	return q
+}
+
+// IgnoreUnmapped sets whether to match no documents, rather than fail, if the
+// field is not mapped.
+func (q *GeoBoundingBoxQuery) IgnoreUnmapped(b bool) *GeoBoundingBoxQuery {
+	q.params.ignoreUnmapped = &b
+	return q
+}
+
+// Boost sets the boost value of the query.
+func (q *GeoBoundingBoxQuery) Boost(b float32) *GeoBoundingBoxQuery {
+	q.params.boost = &b
+	return q
+}
+
+// Map returns a map representation of the query, thus implementing the
+// Mappable interface.
+func (q *GeoBoundingBoxQuery) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		q.field: map[string]interface{}{
+			"top_left":     q.topLeft.Map(),
+			"bottom_right": q.bottomRight.Map(),
+		},
+	}
+	q.params.apply(innerMap)
+
+	return map[string]interface{}{
+		"geo_bounding_box": innerMap,
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// GeoPolygonQuery represents a query of type "geo_polygon", as described in:
+// https://opensearch.org/docs/latest/query-dsl/geo-and-xy/geopolygon/
+type GeoPolygonQuery struct {
+	field  string
+	points []GeoPoint
+	params geoQueryParams
+}
+
+// GeoPolygon creates a new query of type "geo_polygon", matching documents
+// whose point in the provided field is within the polygon made of the
+// provided points.
+func GeoPolygon(field string, points ...GeoPoint) *GeoPolygonQuery {
+	return &GeoPolygonQuery{
+		field:  field,
+		points: points,
+	}
+}
+
+// ValidationMethod sets how invalid coordinates are handled.
+func (q *GeoPolygonQuery) ValidationMethod(m GeoValidationMethod) *GeoPolygonQuery {
+	q.params.validationMethod = m
+	return q
+}
+
+// IgnoreUnmapped sets whether to match no documents, rather than fail, if the
+// field is not mapped.
+func (q *GeoPolygonQuery) IgnoreUnmapped(b bool) *GeoPolygonQuery {
+	q.params.ignoreUnmapped = &b
+	return q
+}
+
+// Boost sets the boost value of the query.
+func (q *GeoPolygonQuery) Boost(b float32) *GeoPolygonQuery {
+	q.params.boost = &b
+	return q
+}
+
+// Map returns a map representation of the query, thus implementing the
+// Mappable interface.
+func (q *GeoPolygonQuery) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		q.field: map[string]interface{}{
+			"points": geoPointsMap(q.points),
+		},
+	}
+	q.params.apply(innerMap)
+
+	return map[string]interface{}{
+		"geo_polygon": innerMap,
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// GeoShapeRelation is an enumeration type for the spatial relation of a
+// "geo_shape" query.
+type GeoShapeRelation string
+
+const (
+	// GeoShapeIntersects matches shapes intersecting the query shape. It is
+	// the default.
+	GeoShapeIntersects GeoShapeRelation = "INTERSECTS"
+
+	// GeoShapeDisjoint matches shapes having nothing in common with the query
+	// shape.
+	GeoShapeDisjoint GeoShapeRelation = "DISJOINT"
+
+	// GeoShapeWithin matches shapes within the query shape.
+	GeoShapeWithin GeoShapeRelation = "WITHIN"
+
+	// GeoShapeContains matches shapes containing the query shape.
+	GeoShapeContains GeoShapeRelation = "CONTAINS"
+)
+
+// GeoShapeQuery represents a query of type "geo_shape", as described in:
+// https://opensearch.org/docs/latest/query-dsl/geo-and-xy/geoshape/
+type GeoShapeQuery struct {
+	field          string
+	shape          *Shape
+	indexedShape   map[string]interface{}
+	relation       GeoShapeRelation
+	ignoreUnmapped *bool
+	boost          *float32
+}
+
+// GeoShape creates a new query of type "geo_shape" on the provided field,
+// which may be a "geo_shape" or a "geo_point" field. The shape to compare
+// with must be set via Shape or IndexedShape.
+func GeoShape(field string) *GeoShapeQuery {
+	return &GeoShapeQuery{field: field}
+}
+
+// Shape sets the shape to compare documents with.
+func (q *GeoShapeQuery) Shape(shape *Shape) *GeoShapeQuery {
+	q.shape = shape
+	q.indexedShape = nil
+	return q
+}
+
+// IndexedShape compares documents with a shape already indexed in the
+// provided index, as the value of path in the document with the provided ID.
+// If path is empty, the "shape" field is used.
+func (q *GeoShapeQuery) IndexedShape(index, id, path string) *GeoShapeQuery {
+	q.indexedShape = map[string]interface{}{
+		"index": index,
+		"id":    id,
+	}
+	if path != "" {
+		q.indexedShape["path"] = path
+	}
+	q.shape = nil
+	return q
+}
+
+// Relation sets the spatial relation documents must have with the shape.
+func (q *GeoShapeQuery) Relation(r GeoShapeRelation) *GeoShapeQuery {
+	q.relation = r
+	return q
+}
+
+// IgnoreUnmapped sets whether to match no documents, rather than fail, if the
+// field is not mapped.
+func (q *GeoShapeQuery) IgnoreUnmapped(b bool) *GeoShapeQuery {
+	q.ignoreUnmapped = &b
+	return q
+}
+
+// Boost sets the boost value of the query.
+func (q *GeoShapeQuery) Boost(b float32) *GeoShapeQuery {
+	q.boost = &b
+	return q
+}
+
+// Map returns a map representation of the query, thus implementing the
+// Mappable interface.
+func (q *GeoShapeQuery) Map() map[string]interface{} {
+	fieldMap := make(map[string]interface{})
+	if q.shape != nil {
+		fieldMap["shape"] = q.shape.Map()
+	}
+	if q.indexedShape != nil {
+		fieldMap["indexed_shape"] = q.indexedShape
+	}
+	if q.relation != "" {
+		fieldMap["relation"] = q.relation
+	}
+
+	innerMap := map[string]interface{}{
+		q.field: fieldMap,
+	}
+	if q.ignoreUnmapped != nil {
+		innerMap["ignore_unmapped"] = *q.ignoreUnmapped
+	}
+	if q.boost != nil {
+		innerMap["boost"] = *q.boost
+	}
+
+	return map[string]interface{}{
+		"geo_shape": innerMap,
+	}
+}
@@ -0,0 +1,146 @@
+package osquery
+
+import (
+	"testing"
+)
+
+func TestGeoQueries(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"geo_distance",
+			GeoDistance("location", LatLon(40.7, -73.9), "12km").
+				DistanceType(GeoDistancePlane).
+				ValidationMethod(GeoValidationCoerce),
+			map[string]interface{}{
+				"geo_distance": map[string]interface{}{
+					"distance":          "12km",
+					"distance_type":     "plane",
+					"validation_method": "COERCE",
+					"location": map[string]interface{}{
+						"lat": 40.7,
+						"lon": -73.9,
+					},
+				},
+			},
+		},
+		{
+			"geo_bounding_box",
+			GeoBoundingBox("location", LatLon(40.73, -74.1), LatLon(40.01, -71.12)).
+				IgnoreUnmapped(true),
+			map[string]interface{}{
+				"geo_bounding_box": map[string]interface{}{
+					"ignore_unmapped": true,
+					"location": map[string]interface{}{
+						"top_left":     map[string]interface{}{"lat": 40.73, "lon": -74.1},
+						"bottom_right": map[string]interface{}{"lat": 40.01, "lon": -71.12},
+					},
+				},
+			},
+		},
+		{
+			"geo_polygon",
+			GeoPolygon("location", LatLon(40, -70), LatLon(30, -80), LatLon(20, -90)).
+				Boost(2),
+			map[string]interface{}{
+				"geo_polygon": map[string]interface{}{
+					"boost": 2,
+					"location": map[string]interface{}{
+						"points": []map[string]interface{}{
+							{"lat": 40, "lon": -70},
+							{"lat": 30, "lon": -80},
+							{"lat": 20, "lon": -90},
+						},
+					},
+				},
+			},
+		},
+		{
+			"geo_shape with envelope",
+			GeoShape("zone").
+				Shape(EnvelopeShape(LatLon(53, 13), LatLon(52, 14))).
+				Relation(GeoShapeWithin),
+			map[string]interface{}{
+				"geo_shape": map[string]interface{}{
+					"zone": map[string]interface{}{
+						"shape": map[string]interface{}{
+							"type":        "envelope",
+							"coordinates": [][]float64{{13, 53}, {14, 52}},
+						},
+						"relation": "WITHIN",
+					},
+				},
+			},
+		},
+		{
+			"geo_shape with polygon",
+			GeoShape("zone").Shape(PolygonShape([]GeoPoint{
+				LatLon(0, 100), LatLon(0, 101), LatLon(1, 101), LatLon(0, 100),
+			})),
+			map[string]interface{}{
+				"geo_shape": map[string]interface{}{
+					"zone": map[string]interface{}{
+						"shape": map[string]interface{}{
+							"type": "polygon",
+							"coordinates": [][][]float64{
+								{{100, 0}, {101, 0}, {101, 1}, {100, 0}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"geo_shape with indexed shape",
+			GeoShape("zone").
+				IndexedShape("shapes", "deu", "location").
+				Relation(GeoShapeIntersects).
+				IgnoreUnmapped(false),
+			map[string]interface{}{
+				"geo_shape": map[string]interface{}{
+					"ignore_unmapped": false,
+					"zone": map[string]interface{}{
+						"indexed_shape": map[string]interface{}{
+							"index": "shapes",
+							"id":    "deu",
+							"path":  "location",
+						},
+						"relation": "INTERSECTS",
+					},
+				},
+			},
+		},
+		{
+			"point and linestring shapes",
+			Bool().Filter(
+				GeoShape("a").Shape(PointShape(LatLon(1, 2))),
+				GeoShape("b").Shape(LineStringShape(LatLon(1, 2), LatLon(3, 4))),
+			),
+			map[string]interface{}{
+				"bool": map[string]interface{}{
+					"filter": []map[string]interface{}{
+						{
+							"geo_shape": map[string]interface{}{
+								"a": map[string]interface{}{
+									"shape": map[string]interface{}{
+										"type":        "point",
+										"coordinates": []float64{2, 1},
+									},
+								},
+							},
+						},
+						{
+							"geo_shape": map[string]interface{}{
+								"b": map[string]interface{}{
+									"shape": map[string]interface{}{
+										"type":        "linestring",
+										"coordinates": [][]float64{{2, 1}, {4, 3}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,476 @@
+package osquery
+
+// IntervalsQuery represents a query of type "intervals", as described in:
+// https://opensearch.org/docs/latest/query-dsl/full-text/intervals/
+// It matches documents based on the order and proximity of terms, as
+// described by a tree of rules.
+type IntervalsQuery struct {
+	field string
+	rule  IntervalsRule
+}
+
+// Intervals creates a new query of type "intervals" on the provided field.
+// The rule matching intervals must be set via Rule.
+func Intervals(field string) *IntervalsQuery {
+	return &IntervalsQuery{field: field}
+}
+
+// Rule sets the rule matching intervals in the field.
+func (q *IntervalsQuery) Rule(rule IntervalsRule) *IntervalsQuery {
+	q.rule = rule
+	return q
+}
+
+// Map returns a map representation of the query, thus implementing the
+// Mappable interface.
+func (q *IntervalsQuery) Map() map[string]interface{} {
+	rule := make(map[string]interface{})
+	if q.rule != nil {
+		rule = q.rule.Map()
+	}
+
+	return map[string]interface{}{
+		"intervals": map[string]interface{}{
+			q.field: rule,
+		},
+	}
+}
+
+// IntervalsRule is a rule of an intervals query. Rules are created with
+// IntervalsMatch, IntervalsPrefix, IntervalsWildcard, IntervalsFuzzy,
+// IntervalsAllOf and IntervalsAnyOf, and can be nested in one another.
+type IntervalsRule interface {
+	Mappable
+	intervalsRule()
+}
+
+// intervalsTermParams holds the options shared by the rules matching terms.
+type intervalsTermParams struct {
+	analyzer string
+	useField string
+}
+
+func (params *intervalsTermParams) apply(innerMap map[string]interface{}) {
+	if params.analyzer != "" {
+		innerMap["analyzer"] = params.analyzer
+	}
+	if params.useField != "" {
+		innerMap["use_field"] = params.useField
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// IntervalsMatchRule represents an intervals rule of type "match", matching
+// analyzed text.
+type IntervalsMatchRule struct {
+	query   string
+	maxGaps *int
+	ordered *bool
+	filter  *IntervalsFilter
+	params  intervalsTermParams
+}
+
+// IntervalsMatch creates a new intervals rule of type "match" with the
+// provided text.
+func IntervalsMatch(query string) *IntervalsMatchRule {
+	return &IntervalsMatchRule{query: query}
+}
+
+func (r *IntervalsMatchRule) intervalsRule() {}
+
+// MaxGaps sets the maximum number of positions between the matching terms.
+// The default, -1, means unlimited.
+func (r *IntervalsMatchRule) MaxGaps(n int) *IntervalsMatchRule {
+	r.maxGaps = &n
+	return r
+}
+
+// Ordered sets whether the terms must appear in the order of the text.
+func (r *IntervalsMatchRule) Ordered(b bool) *IntervalsMatchRule {
+	r.ordered = &b
+	return r
+}
+
+// Analyzer sets the analyzer used to convert the text into terms.
+func (r *IntervalsMatchRule) Analyzer(a string) *IntervalsMatchRule {
+	r.params.analyzer = a
+	return r
+}
+
+// UseField sets a field to match instead of the query's field.
+func (r *IntervalsMatchRule) UseField(field string) *IntervalsMatchRule {
+	r.params.useField = field
+	return r
+}
+
+// Filter sets a filter on the matched intervals.
+func (r *IntervalsMatchRule) Filter(filter *IntervalsFilter) *IntervalsMatchRule {
+	r.filter = filter
+	return r
+}
+
+// Map returns a map representation of the rule, thus implementing the
+// Mappable interface.
+func (r *IntervalsMatchRule) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"query": r.query,
+	}
+	if r.maxGaps != nil {
+		innerMap["max_gaps"] = *r.maxGaps
+	}
+	if r.ordered != nil {
+		innerMap["ordered"] = *r.ordered
+	}
+	r.filter.apply(innerMap)
+	r.params.apply(innerMap)
+
+	return map[string]interface{}{
+		"match": innerMap,
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// IntervalsPrefixRule represents an intervals rule of type "prefix", matching
+// terms starting with a prefix.
+type IntervalsPrefixRule struct {
+	prefix string
+	params intervalsTermParams
+}
+
+// IntervalsPrefix creates a new intervals rule of type "prefix".
+func IntervalsPrefix(prefix string) *IntervalsPrefixRule {
+	return &IntervalsPrefixRule{prefix: prefix}
+}
+
+func (r *IntervalsPrefixRule) intervalsRule() {}
+
+// Analyzer sets the analyzer used to normalize the prefix.
+func (r *IntervalsPrefixRule) Analyzer(a string) *IntervalsPrefixRule {
+	r.params.analyzer = a
+	return r
+}
+
+// UseField sets a field to match instead of the query's field.
+func (r *IntervalsPrefixRule) UseField(field string) *IntervalsPrefixRule {
+	r.params.useField = field
+	return r
+}
+
+// Map returns a map representation of the rule, thus implementing the
+// Mappable interface.
+func (r *IntervalsPrefixRule) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"prefix": r.prefix,
+	}
+	r.params.apply(innerMap)
+
+	return map[string]interface{}{
+		"prefix": innerMap,
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// IntervalsWildcardRule represents an intervals rule of type "wildcard",
+// matching terms using a wildcard pattern.
+type IntervalsWildcardRule struct {
+	pattern string
+	params  intervalsTermParams
+}
+
+// IntervalsWildcard creates a new intervals rule of type "wildcard".
+func IntervalsWildcard(pattern string) *IntervalsWildcardRule {
+	return &IntervalsWildcardRule{pattern: pattern}
+}
+
+func (r *IntervalsWildcardRule) intervalsRule() {}
+
+// Analyzer sets the analyzer used to normalize the pattern.
+func (r *IntervalsWildcardRule) Analyzer(a string) *IntervalsWildcardRule {
+	r.params.analyzer = a
+	return r
+}
+
+// UseField sets a field to match instead of the query's field.
+func (r *IntervalsWildcardRule) UseField(field string) *IntervalsWildcardRule {
+	r.params.useField = field
+	return r
+}
+
+// Map returns a map representation of the rule, thus implementing the
+// Mappable interface.
+func (r *IntervalsWildcardRule) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"pattern": r.pattern,
+	}
+	r.params.apply(innerMap)
+
+	return map[string]interface{}{
+		"wildcard": innerMap,
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// IntervalsFuzzyRule represents an intervals rule of type "fuzzy", matching
+// terms similar to a term.
+type IntervalsFuzzyRule struct {
+	term           string
+	fuzziness      string
+	prefixLength   *uint16
+	transpositions *bool
+	params         intervalsTermParams
+}
+
+// IntervalsFuzzy creates a new intervals rule of type "fuzzy".
+func IntervalsFuzzy(term string) *IntervalsFuzzyRule {
+	return &IntervalsFuzzyRule{term: term}
+}
+
+func (r *IntervalsFuzzyRule) intervalsRule() {}
+
+// Fuzziness sets the maximum edit distance allowed for matching.
+func (r *IntervalsFuzzyRule) Fuzziness(f string) *IntervalsFuzzyRule {
+	r.fuzziness = f
+	return r
+}
+
+// PrefixLength sets the number of beginning characters left unchanged.
+func (r *IntervalsFuzzyRule) PrefixLength(l uint16) *IntervalsFuzzyRule {
+	r.prefixLength = &l
+	return r
+}
+
+// Transpositions sets whether edits include transpositions of two adjacent
+// characters.
+func (r *IntervalsFuzzyRule) Transpositions(b bool) *IntervalsFuzzyRule {
+	r.transpositions = &b
+	return r
+}
+
+// Analyzer sets the analyzer used to normalize the term.
+func (r *IntervalsFuzzyRule) Analyzer(a string) *IntervalsFuzzyRule {
+	r.params.analyzer = a
+	return r
+}
+
+// UseField sets a field to match instead of the query's field.
+func (r *IntervalsFuzzyRule) UseField(field string) *IntervalsFuzzyRule {
+	r.params.useField = field
+	return r
+}
+
+// Map returns a map representation of the rule, thus implementing the
+// Mappable interface.
+func (r *IntervalsFuzzyRule) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"term": r.term,
+	}
+	if r.fuzziness != "" {
+		innerMap["fuzziness"] = r.fuzziness
+	}
+	if r.prefixLength != nil {
+		innerMap["prefix_length"] = *r.prefixLength
+	}
+	if r.transpositions != nil {
+		innerMap["transpositions"] = *r.transpositions
+	}
+	r.params.apply(innerMap)
+
+	return map[string]interface{}{
+		"fuzzy": innerMap,
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// IntervalsAllOfRule represents an intervals rule of type "all_of", matching
+// intervals combining the intervals of all its rules.
+type IntervalsAllOfRule struct {
+	intervals []IntervalsRule
+	maxGaps   *int
+	ordered   *bool
+	filter    *IntervalsFilter
+}
+
+// IntervalsAllOf creates a new intervals rule of type "all_of".
+func IntervalsAllOf(rules ...IntervalsRule) *IntervalsAllOfRule {
+	return &IntervalsAllOfRule{intervals: rules}
+}
+
+func (r *IntervalsAllOfRule) intervalsRule() {}
+
+// MaxGaps sets the maximum number of positions between the intervals of the
+// rules. The default, -1, means unlimited.
+func (r *IntervalsAllOfRule) MaxGaps(n int) *IntervalsAllOfRule {
+	r.maxGaps = &n
+	return r
+}
+
+// Ordered sets whether the intervals must appear in the order of the rules.
+func (r *IntervalsAllOfRule) Ordered(b bool) *IntervalsAllOfRule {
+	r.ordered = &b
+	return r
+}
+
+// Filter sets a filter on the matched intervals.
+func (r *IntervalsAllOfRule) Filter(filter *IntervalsFilter) *IntervalsAllOfRule {
+	r.filter = filter
+	return r
+}
+
+// Map returns a map representation of the rule, thus implementing the
+// Mappable interface.
+func (r *IntervalsAllOfRule) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"intervals": intervalsRulesMap(r.intervals),
+	}
+	if r.maxGaps != nil {
+		innerMap["max_gaps"] = *r.maxGaps
+	}
+	if r.ordered != nil {
+		innerMap["ordered"] = *r.ordered
+	}
+	r.filter.apply(innerMap)
+
+	return map[string]interface{}{
+		"all_of": innerMap,
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// IntervalsAnyOfRule represents an intervals rule of type "any_of", matching
+// the intervals of any of its rules.
+type IntervalsAnyOfRule struct {
+	intervals []IntervalsRule
+	filter    *IntervalsFilter
+}
+
+// IntervalsAnyOf creates a new intervals rule of type "any_of".
+func IntervalsAnyOf(rules ...IntervalsRule) *IntervalsAnyOfRule {
+	return &IntervalsAnyOfRule{intervals: rules}
+}
+
+func (r *IntervalsAnyOfRule) intervalsRule() {}
+
+// Filter sets a filter on the matched intervals.
+func (r *IntervalsAnyOfRule) Filter(filter *IntervalsFilter) *IntervalsAnyOfRule {
+	r.filter = filter
+	return r
+}
+
+// Map returns a map representation of the rule, thus implementing the
+// Mappable interface.
+func (r *IntervalsAnyOfRule) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"intervals": intervalsRulesMap(r.intervals),
+	}
+	r.filter.apply(innerMap)
+
+	return map[string]interface{}{
+		"any_of": innerMap,
+	}
+}
+
+func intervalsRulesMap(rules []IntervalsRule) []map[string]interface{} {
+	maps := make([]map[string]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		if rule != nil {
+			maps = append(maps, rule.Map())
+		}
+	}
+	return maps
+}
+
+//----------------------------------------------------------------------------//
+
+// IntervalsFilter represents a filter on the intervals matched by a rule,
+// keeping only those with a given relation to the intervals of another rule,
+// or those accepted by a script.
+type IntervalsFilter struct {
+	relation string
+	rule     IntervalsRule
+	script   *ScriptField
+}
+
+func newIntervalsFilter(relation string, rule IntervalsRule) *IntervalsFilter {
+	return &IntervalsFilter{relation: relation, rule: rule}
+}
+
+// IntervalsContaining keeps intervals containing an interval of rule.
+func IntervalsContaining(rule IntervalsRule) *IntervalsFilter {
+	return newIntervalsFilter("containing", rule)
+}
+
+// IntervalsContainedBy keeps intervals contained by an interval of rule.
+func IntervalsContainedBy(rule IntervalsRule) *IntervalsFilter {
+	return newIntervalsFilter("contained_by", rule)
+}
+
+// IntervalsNotContaining keeps intervals not containing any interval of rule.
+func IntervalsNotContaining(rule IntervalsRule) *IntervalsFilter {
+	return newIntervalsFilter("not_containing", rule)
+}
+
+// IntervalsNotContainedBy keeps intervals not contained by any interval of
+// rule.
+func IntervalsNotContainedBy(rule IntervalsRule) *IntervalsFilter {
+	return newIntervalsFilter("not_contained_by", rule)
+}
+
+// IntervalsOverlapping keeps intervals overlapping an interval of rule.
+func IntervalsOverlapping(rule IntervalsRule) *IntervalsFilter {
+	return newIntervalsFilter("overlapping", rule)
+}
+
+// IntervalsNotOverlapping keeps intervals not overlapping any interval of
+// rule.
+func IntervalsNotOverlapping(rule IntervalsRule) *IntervalsFilter {
+	return newIntervalsFilter("not_overlapping", rule)
+}
+
+// IntervalsBefore keeps intervals occurring before an interval of rule.
+func IntervalsBefore(rule IntervalsRule) *IntervalsFilter {
+	return newIntervalsFilter("before", rule)
+}
+
+// IntervalsAfter keeps intervals occurring after an interval of rule.
+func IntervalsAfter(rule IntervalsRule) *IntervalsFilter {
+	return newIntervalsFilter("after", rule)
+}
+
+// IntervalsScript keeps intervals for which the provided script returns true.
+// The script can access the interval via the "interval" variable, which
+// exposes its start, end and gaps.
+func IntervalsScript(script *ScriptField) *IntervalsFilter {
+	return &IntervalsFilter{relation: "script", script: script}
+}
+
+// Map returns a map representation of the filter, thus implementing the
+// Mappable interface. The map is empty if the filter has no rule or script.
+func (f *IntervalsFilter) Map() map[string]interface{} {
+	if f.script != nil {
+		return map[string]interface{}{
+			f.relation: f.script.Map()["script"],
+		}
+	}
+	if f.rule == nil {
+		return map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		f.relation: f.rule.Map(),
+	}
+}
+
+// apply sets the filter on the provided rule map, unless it is nil or has no
+// rule or script.
+func (f *IntervalsFilter) apply(innerMap map[string]interface{}) {
+	if f == nil || (f.rule == nil && f.script == nil) {
+		return
+	}
+	innerMap["filter"] = f.Map()
+}
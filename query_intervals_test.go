@@ -0,0 +1,172 @@
+package osquery
+
+import (
+	"testing"
+)
+
+func TestIntervals(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"intervals with a match rule",
+			Intervals("body").Rule(
+				IntervalsMatch("hot water").MaxGaps(0).Ordered(true).Analyzer("standard"),
+			),
+			map[string]interface{}{
+				"intervals": map[string]interface{}{
+					"body": map[string]interface{}{
+						"match": map[string]interface{}{
+							"query":    "hot water",
+							"max_gaps": 0,
+							"ordered":  true,
+							"analyzer": "standard",
+						},
+					},
+				},
+			},
+		},
+		{
+			"intervals with nested rules",
+			Intervals("body").Rule(
+				IntervalsAllOf(
+					IntervalsMatch("my favorite food").Ordered(true),
+					IntervalsAnyOf(
+						IntervalsMatch("hot water"),
+						IntervalsPrefix("cold").UseField("body.raw"),
+						IntervalsWildcard("po*ridge"),
+						IntervalsFuzzy("oatmeal").Fuzziness("AUTO").PrefixLength(1).Transpositions(false),
+					),
+				).Ordered(true).MaxGaps(-1),
+			),
+			map[string]interface{}{
+				"intervals": map[string]interface{}{
+					"body": map[string]interface{}{
+						"all_of": map[string]interface{}{
+							"ordered":  true,
+							"max_gaps": -1,
+							"intervals": []map[string]interface{}{
+								{
+									"match": map[string]interface{}{
+										"query":   "my favorite food",
+										"ordered": true,
+									},
+								},
+								{
+									"any_of": map[string]interface{}{
+										"intervals": []map[string]interface{}{
+											{"match": map[string]interface{}{"query": "hot water"}},
+											{"prefix": map[string]interface{}{"prefix": "cold", "use_field": "body.raw"}},
+											{"wildcard": map[string]interface{}{"pattern": "po*ridge"}},
+											{"fuzzy": map[string]interface{}{
+												"term":           "oatmeal",
+												"fuzziness":      "AUTO",
+												"prefix_length":  1,
+												"transpositions": false,
+											}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"intervals with nil rules",
+			Intervals("body").Rule(
+				IntervalsAnyOf(IntervalsMatch("hot"), nil).Filter(IntervalsBefore(nil)),
+			),
+			map[string]interface{}{
+				"intervals": map[string]interface{}{
+					"body": map[string]interface{}{
+						"any_of": map[string]interface{}{
+							"intervals": []map[string]interface{}{
+								{"match": map[string]interface{}{"query": "hot"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"intervals filter without rule",
+			IntervalsContaining(nil),
+			map[string]interface{}{},
+		},
+		{
+			"intervals with filters",
+			Intervals("body").Rule(
+				IntervalsAnyOf(IntervalsMatch("hot"), IntervalsMatch("cold")).
+					Filter(IntervalsNotContainedBy(IntervalsMatch("salty"))),
+			),
+			map[string]interface{}{
+				"intervals": map[string]interface{}{
+					"body": map[string]interface{}{
+						"any_of": map[string]interface{}{
+							"intervals": []map[string]interface{}{
+								{"match": map[string]interface{}{"query": "hot"}},
+								{"match": map[string]interface{}{"query": "cold"}},
+							},
+							"filter": map[string]interface{}{
+								"not_contained_by": map[string]interface{}{
+									"match": map[string]interface{}{"query": "salty"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"intervals with script filter",
+			Intervals("body").Rule(
+				IntervalsMatch("hot porridge").Filter(
+					IntervalsScript(Script("").Source("interval.start > 10 && interval.gaps == 0")),
+				),
+			),
+			map[string]interface{}{
+				"intervals": map[string]interface{}{
+					"body": map[string]interface{}{
+						"match": map[string]interface{}{
+							"query": "hot porridge",
+							"filter": map[string]interface{}{
+								"script": map[string]interface{}{
+									"source": "interval.start > 10 && interval.gaps == 0",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"intervals filters",
+			Bool().Filter(
+				Intervals("a").Rule(IntervalsMatch("x").Filter(IntervalsBefore(IntervalsMatch("y")))),
+				Intervals("b").Rule(IntervalsMatch("x").Filter(IntervalsNotOverlapping(IntervalsMatch("y")))),
+			),
+			map[string]interface{}{
+				"bool": map[string]interface{}{
+					"filter": []map[string]interface{}{
+						{"intervals": map[string]interface{}{"a": map[string]interface{}{
+							"match": map[string]interface{}{
+								"query": "x",
+								"filter": map[string]interface{}{
+									"before": map[string]interface{}{"match": map[string]interface{}{"query": "y"}},
+								},
+							},
+						}}},
+						{"intervals": map[string]interface{}{"b": map[string]interface{}{
+							"match": map[string]interface{}{
+								"query": "x",
+								"filter": map[string]interface{}{
+									"not_overlapping": map[string]interface{}{"match": map[string]interface{}{"query": "y"}},
+								},
+							},
+						}}},
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,191 @@
+package osquery
+
+import "github.com/fatih/structs"
+
+// HasChildQuery represents a compound query of type "has_child", as described
+// in https://opensearch.org/docs/latest/query-dsl/joining/has-child/
+// It matches parent documents whose child documents match the query.
+type HasChildQuery struct {
+	childType      string
+	query          Mappable
+	name           string
+	scoreMode      ScoreModeType
+	minChildren    *int
+	maxChildren    *int
+	ignoreUnmapped *bool
+	innerHits      *InnerHitsOptions
+}
+
+// HasChild creates a new query of type "has_child" with the provided child
+// relation name, as defined in the join field, and query.
+func HasChild(childType string, query Mappable) *HasChildQuery {
+	return &HasChildQuery{
+		childType: childType,
+		query:     query,
+	}
+}
+
+// ScoreMode sets how the scores of matching children are combined into the
+// score of the parent. The default is ScoreModeNone.
+func (q *HasChildQuery) ScoreMode(mode ScoreModeType) *HasChildQuery {
+	q.scoreMode = mode
+	return q
+}
+
+// MinChildren sets the minimum number of matching children a parent must have.
+func (q *HasChildQuery) MinChildren(n int) *HasChildQuery {
+	q.minChildren = &n
+	return q
+}
+
+// MaxChildren sets the maximum number of matching children a parent may have.
+func (q *HasChildQuery) MaxChildren(n int) *HasChildQuery {
+	q.maxChildren = &n
+	return q
+}
+
+// IgnoreUnmapped sets whether to match no documents, rather than fail, if the
+// child relation is not mapped.
+func (q *HasChildQuery) IgnoreUnmapped(b bool) *HasChildQuery {
+	q.ignoreUnmapped = &b
+	return q
+}
+
+// InnerHits sets the inner_hits field of the query, returning the matching
+// children with each parent.
+func (q *HasChildQuery) InnerHits(innerHits *InnerHitsOptions) *HasChildQuery {
+	q.innerHits = innerHits
+	return q
+}
+
+func (q *HasChildQuery) Name(name string) *HasChildQuery {
+	q.name = name
+	return q
+}
+
+// Map returns a map representation of the query, implementing the Mappable interface.
+func (q *HasChildQuery) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"has_child": structs.Map(struct {
+			Type           string                 `structs:"type"`
+			Query          map[string]interface{} `structs:"query"`
+			Name           string                 `structs:"_name,omitempty"`
+			ScoreMode      ScoreModeType          `structs:"score_mode,omitempty"`
+			MinChildren    *int                   `structs:"min_children,omitempty"`
+			MaxChildren    *int                   `structs:"max_children,omitempty"`
+			IgnoreUnmapped *bool                  `structs:"ignore_unmapped,omitempty"`
+			InnerHits      map[string]interface{} `structs:"inner_hits,omitempty"`
+		}{
+			q.childType, q.query.Map(), q.name, q.scoreMode,
+			q.minChildren, q.maxChildren, q.ignoreUnmapped, innerHitsMap(q.innerHits),
+		}),
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// HasParentQuery represents a compound query of type "has_parent", as
+// described in https://opensearch.org/docs/latest/query-dsl/joining/has-parent/
+// It matches child documents whose parent document matches the query.
+type HasParentQuery struct {
+	parentType     string
+	query          Mappable
+	name           string
+	score          *bool
+	ignoreUnmapped *bool
+	innerHits      *InnerHitsOptions
+}
+
+// HasParent creates a new query of type "has_parent" with the provided parent
+// relation name, as defined in the join field, and query.
+func HasParent(parentType string, query Mappable) *HasParentQuery {
+	return &HasParentQuery{
+		parentType: parentType,
+		query:      query,
+	}
+}
+
+// Score sets whether the score of the matching parent is given to its
+// children. By default, children get a constant score.
+func (q *HasParentQuery) Score(b bool) *HasParentQuery {
+	q.score = &b
+	return q
+}
+
+// IgnoreUnmapped sets whether to match no documents, rather than fail, if the
+// parent relation is not mapped.
+func (q *HasParentQuery) IgnoreUnmapped(b bool) *HasParentQuery {
+	q.ignoreUnmapped = &b
+	return q
+}
+
+// InnerHits sets the inner_hits field of the query, returning the matching
+// parent with each child.
+func (q *HasParentQuery) InnerHits(innerHits *InnerHitsOptions) *HasParentQuery {
+	q.innerHits = innerHits
+	return q
+}
+
+func (q *HasParentQuery) Name(name string) *HasParentQuery {
+	q.name = name
+	return q
+}
+
+// Map returns a map representation of the query, implementing the Mappable interface.
+func (q *HasParentQuery) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"has_parent": structs.Map(struct {
+			ParentType     string                 `structs:"parent_type"`
+			Query          map[string]interface{} `structs:"query"`
+			Name           string                 `structs:"_name,omitempty"`
+			Score          *bool                  `structs:"score,omitempty"`
+			IgnoreUnmapped *bool                  `structs:"ignore_unmapped,omitempty"`
+			InnerHits      map[string]interface{} `structs:"inner_hits,omitempty"`
+		}{q.parentType, q.query.Map(), q.name, q.score, q.ignoreUnmapped, innerHitsMap(q.innerHits)}),
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// ParentIDQuery represents a query of type "parent_id", as described in
+// https://opensearch.org/docs/latest/query-dsl/joining/parent-id/
+// It matches the child documents of a specific parent document.
+type ParentIDQuery struct {
+	childType      string
+	id             string
+	name           string
+	ignoreUnmapped *bool
+}
+
+// ParentID creates a new query of type "parent_id", matching the children of
+// the provided relation name whose parent has the provided ID.
+func ParentID(childType, id string) *ParentIDQuery {
+	return &ParentIDQuery{
+		childType: childType,
+		id:        id,
+	}
+}
+
+// IgnoreUnmapped sets whether to match no documents, rather than fail, if the
+// child relation is not mapped.
+func (q *ParentIDQuery) IgnoreUnmapped(b bool) *ParentIDQuery {
+	q.ignoreUnmapped = &b
+	return q
+}
+
+func (q *ParentIDQuery) Name(name string) *ParentIDQuery {
+	q.name = name
+	return q
+}
+
+// Map returns a map representation of the query, implementing the Mappable interface.
+func (q *ParentIDQuery) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"parent_id": structs.Map(struct {
+			Type           string `structs:"type"`
+			ID             string `structs:"id"`
+			Name           string `structs:"_name,omitempty"`
+			IgnoreUnmapped *bool  `structs:"ignore_unmapped,omitempty"`
+		}{q.childType, q.id, q.name, q.ignoreUnmapped}),
+	}
+}
@@ -0,0 +1,77 @@
+package osquery
+
+import (
+	"testing"
+)
+
+func TestJoiningQueries(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"has_child query",
+			HasChild("line_item", Term("sku", "A-1")),
+			map[string]interface{}{
+				"has_child": map[string]interface{}{
+					"type": "line_item",
+					"query": map[string]interface{}{
+						"term": map[string]interface{}{
+							"sku": map[string]interface{}{"value": "A-1"},
+						},
+					},
+				},
+			},
+		},
+		{
+			"has_child query with all options",
+			HasChild("line_item", MatchAll()).
+				ScoreMode(ScoreModeMax).
+				MinChildren(2).
+				MaxChildren(10).
+				IgnoreUnmapped(true).
+				InnerHits(InnerHits().Size(3)).
+				Name("orders_with_items"),
+			map[string]interface{}{
+				"has_child": map[string]interface{}{
+					"type":            "line_item",
+					"query":           map[string]interface{}{"match_all": map[string]interface{}{}},
+					"score_mode":      "max",
+					"min_children":    2,
+					"max_children":    10,
+					"ignore_unmapped": true,
+					"inner_hits":      map[string]interface{}{"size": 3},
+					"_name":           "orders_with_items",
+				},
+			},
+		},
+		{
+			"has_parent query",
+			HasParent("order", Term("status", "shipped")).
+				Score(true).
+				IgnoreUnmapped(false).
+				InnerHits(InnerHits()),
+			map[string]interface{}{
+				"has_parent": map[string]interface{}{
+					"parent_type": "order",
+					"query": map[string]interface{}{
+						"term": map[string]interface{}{
+							"status": map[string]interface{}{"value": "shipped"},
+						},
+					},
+					"score":           true,
+					"ignore_unmapped": false,
+					"inner_hits":      map[string]interface{}{},
+				},
+			},
+		},
+		{
+			"parent_id query",
+			ParentID("line_item", "order-1").IgnoreUnmapped(true),
+			map[string]interface{}{
+				"parent_id": map[string]interface{}{
+					"type":            "line_item",
+					"id":              "order-1",
+					"ignore_unmapped": true,
+				},
+			},
+		},
+	})
+}
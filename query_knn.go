@@ -0,0 +1,265 @@
+package osquery
+
+// KNNQuery represents a query of type "knn", provided by the k-NN plugin, as
+// described in:
+// https://opensearch.org/docs/latest/search-plugins/knn/approximate-knn/
+type KNNQuery struct {
+	field  string
+	vector []float32
+	params vectorQueryParams
+}
+
+// vectorQueryParams holds the options shared by the "knn" and "neural"
+// queries.
+type vectorQueryParams struct {
+	k           uint
+	filter      Mappable
+	minScore    *float32
+	maxDistance *float32
+	efSearch    *uint
+	nprobes     *uint
+	boost       *float32
+}
+
+func (params *vectorQueryParams) apply(innerMap map[string]interface{}) {
+	if params.k > 0 {
+		innerMap["k"] = params.k
+	}
+	if params.filter != nil {
+		innerMap["filter"] = params.filter.Map()
+	}
+	if params.minScore != nil {
+		innerMap["min_score"] = *params.minScore
+	}
+	if params.maxDistance != nil {
+		innerMap["max_distance"] = *params.maxDistance
+	}
+	if params.efSearch != nil || params.nprobes != nil {
+		methodParams := make(map[string]interface{})
+		if params.efSearch != nil {
+			methodParams["ef_search"] = *params.efSearch
+		}
+		if params.nprobes != nil {
+			methodParams["nprobes"] = *params.nprobes
+		}
+		innerMap["method_parameters"] = methodParams
+	}
+	if params.boost != nil {
+		innerMap["boost"] = *params.boost
+	}
+}
+
+// KNN creates a new query of type "knn", returning the k nearest neighbors of
+// the provided vector in the provided field. For a radial search, pass a k of
+// 0 and set MinScore or MaxDistance instead.
+func KNN(field string, vector []float32, k uint) *KNNQuery {
+	return &KNNQuery{
+		field:  field,
+		vector: vector,
+		params: vectorQueryParams{k: k},
+	}
+}
+
+// Filter sets a query restricting the documents searched for neighbors.
+func (q *KNNQuery) Filter(filter Mappable) *KNNQuery {
+	q.params.filter = filter
+	return q
+}
+
+// MinScore sets the minimum score of returned neighbors, for a radial search.
+func (q *KNNQuery) MinScore(min float32) *KNNQuery {
+	q.params.minScore = &min
+	return q
+}
+
+// MaxDistance sets the maximum distance of returned neighbors, for a radial
+// search.
+func (q *KNNQuery) MaxDistance(max float32) *KNNQuery {
+	q.params.maxDistance = &max
+	return q
+}
+
+// EfSearch sets the "ef_search" method parameter, i.e. the number of vectors
+// examined by HNSW indices.
+func (q *KNNQuery) EfSearch(ef uint) *KNNQuery {
+	q.params.efSearch = &ef
+	return q
+}
+
+// NProbes sets the "nprobes" method parameter, i.e. the number of buckets
+// examined by IVF indices.
+func (q *KNNQuery) NProbes(n uint) *KNNQuery {
+	q.params.nprobes = &n
+	return q
+}
+
+// Boost sets the boost value of the query.
+func (q *KNNQuery) Boost(b float32) *KNNQuery {
+	q.params.boost = &b
+	return q
+}
+
+// Map returns a map representation of the query, thus implementing the
+// Mappable interface.
+func (q *KNNQuery) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"vector": q.vector,
+	}
+	q.params.apply(innerMap)
+
+	return map[string]interface{}{
+		"knn": map[string]interface{}{
+			q.field: innerMap,
+		},
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// NeuralQuery represents a query of type "neural", provided by the neural
+// search plugin, as described in:
+// https://opensearch.org/docs/latest/query-dsl/specialized/neural/
+// The query text (or image) is converted into a vector by the provided model
+// before searching for neighbors.
+type NeuralQuery struct {
+	field      string
+	queryText  string
+	queryImage string
+	modelID    string
+	params     vectorQueryParams
+}
+
+// Neural creates a new query of type "neural" on the provided vector field,
+// embedding the provided text with the provided model. The model ID may be
+// empty if the index or search pipeline defines a default model.
+func Neural(field, queryText, modelID string) *NeuralQuery {
+	return &NeuralQuery{
+		field:     field,
+		queryText: queryText,
+		modelID:   modelID,
+	}
+}
+
+// QueryImage sets a base64-encoded image to embed, for multimodal models.
+func (q *NeuralQuery) QueryImage(image string) *NeuralQuery {
+	q.queryImage = image
+	return q
+}
+
+// K sets the number of neighbors to return.
+func (q *NeuralQuery) K(k uint) *NeuralQuery {
+	q.params.k = k
+	return q
+}
+
+// Filter sets a query restricting the documents searched for neighbors.
+func (q *NeuralQuery) Filter(filter Mappable) *NeuralQuery {
+	q.params.filter = filter
+	return q
+}
+
+// MinScore sets the minimum score of returned neighbors, for a radial search.
+func (q *NeuralQuery) MinScore(min float32) *NeuralQuery {
+	q.params.minScore = &min
+	return q
+}
+
+// MaxDistance sets the maximum distance of returned neighbors, for a radial
+// search.
+func (q *NeuralQuery) MaxDistance(max float32) *NeuralQuery {
+	q.params.maxDistance = &max
+	return q
+}
+
+// EfSearch sets the "ef_search" method parameter, i.e. the number of vectors
+// examined by HNSW indices.
+func (q *NeuralQuery) EfSearch(ef uint) *NeuralQuery {
+	q.params.efSearch = &ef
+	return q
+}
+
+// NProbes sets the "nprobes" method parameter, i.e. the number of buckets
+// examined by IVF indices.
+func (q *NeuralQuery) NProbes(n uint) *NeuralQuery {
+	q.params.nprobes = &n
+	return q
+}
+
+// Boost sets the boost value of the query.
+func (q *NeuralQuery) Boost(b float32) *NeuralQuery {
+	q.params.boost = &b
+	return q
+}
+
+// Map returns a map representation of the query, thus implementing the
+// Mappable interface.
+func (q *NeuralQuery) Map() map[string]interface{} {
+	innerMap := make(map[string]interface{})
+	if q.queryText != "" {
+		innerMap["query_text"] = q.queryText
+	}
+	if q.queryImage != "" {
+		innerMap["query_image"] = q.queryImage
+	}
+	if q.modelID != "" {
+		innerMap["model_id"] = q.modelID
+	}
+	q.params.apply(innerMap)
+
+	return map[string]interface{}{
+		"neural": map[string]interface{}{
+			q.field: innerMap,
+		},
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// HybridQuery represents a query of type "hybrid", as described in:
+// https://opensearch.org/docs/latest/query-dsl/compound/hybrid/
+// It runs several queries, typically a lexical and a neural one, whose scores
+// are normalized and combined by a search pipeline (see
+// SearchRequest.SearchPipeline).
+type HybridQuery struct {
+	queries []Mappable
+	filter  Mappable
+}
+
+// Hybrid creates a new query of type "hybrid" with the provided sub-queries.
+func Hybrid(queries ...Mappable) *HybridQuery {
+	return &HybridQuery{
+		queries: queries,
+	}
+}
+
+// Queries adds sub-queries to the query.
+func (q *HybridQuery) Queries(queries ...Mappable) *HybridQuery {
+	q.queries = append(q.queries, queries...)
+	return q
+}
+
+// Filter sets a query applied to every sub-query.
+func (q *HybridQuery) Filter(filter Mappable) *HybridQuery {
+	q.filter = filter
+	return q
+}
+
+// Map returns a map representation of the query, thus implementing the
+// Mappable interface.
+func (q *HybridQuery) Map() map[string]interface{} {
+	queries := make([]map[string]interface{}, len(q.queries))
+	for i, sub := range q.queries {
+		queries[i] = sub.Map()
+	}
+
+	innerMap := map[string]interface{}{
+		"queries": queries,
+	}
+	if q.filter != nil {
+		innerMap["filter"] = q.filter.Map()
+	}
+
+	return map[string]interface{}{
+		"hybrid": innerMap,
+	}
+}
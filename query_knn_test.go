@@ -0,0 +1,99 @@
+package osquery
+
+import (
+	"testing"
+)
+
+func TestVectorQueries(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"knn",
+			KNN("embedding", []float32{0.1, 0.2, 0.3}, 10).
+				Filter(Term("lang", "en")).
+				EfSearch(100).
+				Boost(2),
+			map[string]interface{}{
+				"knn": map[string]interface{}{
+					"embedding": map[string]interface{}{
+						"vector": []float32{0.1, 0.2, 0.3},
+						"k":      10,
+						"filter": map[string]interface{}{
+							"term": map[string]interface{}{
+								"lang": map[string]interface{}{"value": "en"},
+							},
+						},
+						"method_parameters": map[string]interface{}{
+							"ef_search": 100,
+						},
+						"boost": 2,
+					},
+				},
+			},
+		},
+		{
+			"knn radial search",
+			KNN("embedding", []float32{1, 2}, 0).MaxDistance(4.5).NProbes(8),
+			map[string]interface{}{
+				"knn": map[string]interface{}{
+					"embedding": map[string]interface{}{
+						"vector":       []float32{1, 2},
+						"max_distance": float32(4.5),
+						"method_parameters": map[string]interface{}{
+							"nprobes": 8,
+						},
+					},
+				},
+			},
+		},
+		{
+			"neural",
+			Neural("passage_embedding", "wild west", "aVeif4oB5Vm0Tdw8zYO2").
+				K(5).
+				MinScore(0.4),
+			map[string]interface{}{
+				"neural": map[string]interface{}{
+					"passage_embedding": map[string]interface{}{
+						"query_text": "wild west",
+						"model_id":   "aVeif4oB5Vm0Tdw8zYO2",
+						"k":          5,
+						"min_score":  float32(0.4),
+					},
+				},
+			},
+		},
+		{
+			"hybrid",
+			Hybrid(
+				Match("text").Query("wild west"),
+				Neural("passage_embedding", "wild west", "").QueryImage("aGVsbG8=").K(5),
+			).Filter(Term("genre", "western")),
+			map[string]interface{}{
+				"hybrid": map[string]interface{}{
+					"queries": []map[string]interface{}{
+						{
+							"match": map[string]interface{}{
+								"text": map[string]interface{}{
+									"query": "wild west",
+								},
+							},
+						},
+						{
+							"neural": map[string]interface{}{
+								"passage_embedding": map[string]interface{}{
+									"query_text":  "wild west",
+									"query_image": "aGVsbG8=",
+									"k":           5,
+								},
+							},
+						},
+					},
+					"filter": map[string]interface{}{
+						"term": map[string]interface{}{
+							"genre": map[string]interface{}{"value": "western"},
+						},
+					},
+				},
+			},
+		},
+	})
+}
@@ -30,7 +30,7 @@ type NestedQuery struct {
 	query     Mappable
 	name      string
 	scoreMode string
-	innerHits map[string]interface{}
+	innerHits *InnerHitsOptions
 }
 
 // Nested creates a new query of type "nested" with the provided path and query.
@@ -48,7 +48,7 @@ func (q *NestedQuery) ScoreMode(mode ScoreModeType) *NestedQuery {
 }
 
 // InnerHits sets the inner_hits field of the query.
-func (q *NestedQuery) InnerHits(innerHits map[string]interface{}) *NestedQuery {
+func (q *NestedQuery) InnerHits(innerHits *InnerHitsOptions) *NestedQuery {
 	q.innerHits = innerHits
 	return q
 }
@@ -67,6 +67,6 @@ func (q *NestedQuery) Map() map[string]interface{} {
 			Name      string                 `structs:"_name,omitempty"`
 			ScoreMode string                 `structs:"score_mode,omitempty"`
 			InnerHits map[string]interface{} `structs:"inner_hits,omitempty"`
-		}{q.path, q.query.Map(), q.name, q.scoreMode, q.innerHits}),
+		}{q.path, q.query.Map(), q.name, q.scoreMode, innerHitsMap(q.innerHits)}),
 	}
 }
@@ -40,7 +40,7 @@ func TestNestedQuery(t *testing.T) {
 		},
 		{
 			"nested query with inner_hits",
-			Nested("comments", Term("user", "kimchy")).InnerHits(map[string]interface{}{"size": 3}),
+			Nested("comments", Term("user", "kimchy")).InnerHits(InnerHits().Size(3)),
 			map[string]interface{}{
 				"nested": map[string]interface{}{
 					"path": "comments",
@@ -0,0 +1,448 @@
+package osquery
+
+// SpanQuery is a query of the span family, matching positions of terms
+// rather than whole documents. Span queries can only be combined with other
+// span queries, which is enforced by this interface: compound span queries
+// only accept SpanQuery clauses.
+type SpanQuery interface {
+	Mappable
+	spanQuery()
+}
+
+// MultiTermQuery is a term-level query that can be wrapped into a span query
+// via SpanMulti: "prefix", "wildcard", "regexp", "fuzzy" and "range" queries.
+type MultiTermQuery interface {
+	Mappable
+	multiTermQuery()
+}
+
+func (q *PrefixQuery) multiTermQuery() {}
+func (q *RegexpQuery) multiTermQuery() {}
+func (q *FuzzyQuery) multiTermQuery()  {}
+func (q *RangeQuery) multiTermQuery()  {}
+
+func spanClausesMap(clauses []SpanQuery) []map[string]interface{} {
+	maps := make([]map[string]interface{}, len(clauses))
+	for i, clause := range clauses {
+		maps[i] = clause.Map()
+	}
+	return maps
+}
+
+//----------------------------------------------------------------------------//
+
+// SpanTermQuery represents a query of type "span_term", as described in:
+// https://opensearch.org/docs/latest/query-dsl/span/span-term/
+type SpanTermQuery struct {
+	field string
+	value interface{}
+	boost *float32
+}
+
+// SpanTerm creates a new query of type "span_term", matching spans containing
+// the provided term.
+func SpanTerm(field string, value interface{}) *SpanTermQuery {
+	return &SpanTermQuery{
+		field: field,
+		value: value,
+	}
+}
+
+func (q *SpanTermQuery) spanQuery() {}
+
+// Boost sets the boost value of the query.
+func (q *SpanTermQuery) Boost(b float32) *SpanTermQuery {
+	q.boost = &b
+	return q
+}
+
+// Map returns a map representation of the query, thus implementing the
+// Mappable interface.
+func (q *SpanTermQuery) Map() map[string]interface{} {
+	params := map[string]interface{}{
+		"value": q.value,
+	}
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+
+	return map[string]interface{}{
+		"span_term": map[string]interface{}{
+			q.field: params,
+		},
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// SpanNearQuery represents a query of type "span_near", as described in:
+// https://opensearch.org/docs/latest/query-dsl/span/span-near/
+type SpanNearQuery struct {
+	clauses []SpanQuery
+	slop    *int
+	inOrder *bool
+	boost   *float32
+}
+
+// SpanNear creates a new query of type "span_near", matching spans of the
+// provided clauses that are near one another.
+func SpanNear(clauses ...SpanQuery) *SpanNearQuery {
+	return &SpanNearQuery{
+		clauses: clauses,
+	}
+}
+
+func (q *SpanNearQuery) spanQuery() {}
+
+// Clauses adds clauses to the query.
+func (q *SpanNearQuery) Clauses(clauses ...SpanQuery) *SpanNearQuery {
+	q.clauses = append(q.clauses, clauses...)
+	return q
+}
+
+// Slop sets the maximum number of positions allowed between the clauses.
+func (q *SpanNearQuery) Slop(n int) *SpanNearQuery {
+	q.slop = &n
+	return q
+}
+
+// InOrder sets whether the clauses must appear in order.
+func (q *SpanNearQuery) InOrder(b bool) *SpanNearQuery {
+	q.inOrder = &b
+	return q
+}
+
+// Boost sets the boost value of the query.
+func (q *SpanNearQuery) Boost(b float32) *SpanNearQuery {
+	q.boost = &b
+	return q
+}
+
+// Map returns a map representation of the query, thus implementing the
+// Mappable interface.
+func (q *SpanNearQuery) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"clauses": spanClausesMap(q.clauses),
+	}
+	if q.slop != nil {
+		innerMap["slop"] = *q.slop
+	}
+	if q.inOrder != nil {
+		innerMap["in_order"] = *q.inOrder
+	}
+	if q.boost != nil {
+		innerMap["boost"] = *q.boost
+	}
+
+	return map[string]interface{}{
+		"span_near": innerMap,
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// SpanOrQuery represents a query of type "span_or", as described in:
+// https://opensearch.org/docs/latest/query-dsl/span/span-or/
+type SpanOrQuery struct {
+	clauses []SpanQuery
+	boost   *float32
+}
+
+// SpanOr creates a new query of type "span_or", matching the spans of any of
+// the provided clauses.
+func SpanOr(clauses ...SpanQuery) *SpanOrQuery {
+	return &SpanOrQuery{
+		clauses: clauses,
+	}
+}
+
+func (q *SpanOrQuery) spanQuery() {}
+
+// Clauses adds clauses to the query.
+func (q *SpanOrQuery) Clauses(clauses ...SpanQuery) *SpanOrQuery {
+	q.clauses = append(q.clauses, clauses...)
+	return q
+}
+
+// Boost sets the boost value of the query.
+func (q *SpanOrQuery) Boost(b float32) *SpanOrQuery {
+	q.boost = &b
+	return q
+}
+
+// Map returns a map representation of the query, thus implementing the
+// Mappable interface.
+func (q *SpanOrQuery) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"clauses": spanClausesMap(q.clauses),
+	}
+	if q.boost != nil {
+		innerMap["boost"] = *q.boost
+	}
+
+	return map[string]interface{}{
+		"span_or": innerMap,
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// SpanNotQuery represents a query of type "span_not", as described in:
+// https://opensearch.org/docs/latest/query-dsl/span/span-not/
+type SpanNotQuery struct {
+	include SpanQuery
+	exclude SpanQuery
+	pre     *int
+	post    *int
+	dist    *int
+	boost   *float32
+}
+
+// SpanNot creates a new query of type "span_not", matching the spans of
+// include that don't overlap with the spans of exclude.
+func SpanNot(include, exclude SpanQuery) *SpanNotQuery {
+	return &SpanNotQuery{
+		include: include,
+		exclude: exclude,
+	}
+}
+
+func (q *SpanNotQuery) spanQuery() {}
+
+// Pre sets the number of positions before an include span that must not
+// overlap with an exclude span.
+func (q *SpanNotQuery) Pre(n int) *SpanNotQuery {
+	q.pre = &n
+	return q
+}
+
+// Post sets the number of positions after an include span that must not
+// overlap with an exclude span.
+func (q *SpanNotQuery) Post(n int) *SpanNotQuery {
+	q.post = &n
+	return q
+}
+
+// Dist sets both Pre and Post.
+func (q *SpanNotQuery) Dist(n int) *SpanNotQuery {
+	q.dist = &n
+	return q
+}
+
+// Boost sets the boost value of the query.
+func (q *SpanNotQuery) Boost(b float32) *SpanNotQuery {
+	q.boost = &b
+	return q
+}
+
+// Map returns a map representation of the query, thus implementing the
+// Mappable interface.
+func (q *SpanNotQuery) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"include": q.include.Map(),
+		"exclude": q.exclude.Map(),
+	}
+	if q.pre != nil {
+		innerMap["pre"] = *q.pre
+	}
+	if q.post != nil {
+		innerMap["post"] = *q.post
+	}
+	if q.dist != nil {
+		innerMap["dist"] = *q.dist
+	}
+	if q.boost != nil {
+		innerMap["boost"] = *q.boost
+	}
+
+	return map[string]interface{}{
+		"span_not": innerMap,
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// SpanFirstQuery represents a query of type "span_first", as described in:
+// https://opensearch.org/docs/latest/query-dsl/span/span-first/
+type SpanFirstQuery struct {
+	match SpanQuery
+	end   int
+	boost *float32
+}
+
+// SpanFirst creates a new query of type "span_first", matching the spans of
+// match that end at or before the provided position.
+func SpanFirst(match SpanQuery, end int) *SpanFirstQuery {
+	return &SpanFirstQuery{
+		match: match,
+		end:   end,
+	}
+}
+
+func (q *SpanFirstQuery) spanQuery() {}
+
+// Boost sets the boost value of the query.
+func (q *SpanFirstQuery) Boost(b float32) *SpanFirstQuery {
+	q.boost = &b
+	return q
+}
+
+// Map returns a map representation of the query, thus implementing the
+// Mappable interface.
+func (q *SpanFirstQuery) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"match": q.match.Map(),
+		"end":   q.end,
+	}
+	if q.boost != nil {
+		innerMap["boost"] = *q.boost
+	}
+
+	return map[string]interface{}{
+		"span_first": innerMap,
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// SpanMultiQuery represents a query of type "span_multi", as described in:
+// https://opensearch.org/docs/latest/query-dsl/span/span-multi-term/
+type SpanMultiQuery struct {
+	match MultiTermQuery
+	boost *float32
+}
+
+// SpanMulti creates a new query of type "span_multi", wrapping a multi-term
+// query (such as Prefix or Wildcard) so that it can be used as a span query.
+func SpanMulti(match MultiTermQuery) *SpanMultiQuery {
+	return &SpanMultiQuery{
+		match: match,
+	}
+}
+
+func (q *SpanMultiQuery) spanQuery() {}
+
+// Boost sets the boost value of the query.
+func (q *SpanMultiQuery) Boost(b float32) *SpanMultiQuery {
+	q.boost = &b
+	return q
+}
+
+// Map returns a map representation of the query, thus implementing the
+// Mappable interface.
+func (q *SpanMultiQuery) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"match": q.match.Map(),
+	}
+	if q.boost != nil {
+		innerMap["boost"] = *q.boost
+	}
+
+	return map[string]interface{}{
+		"span_multi": innerMap,
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// SpanContainingQuery represents a query of type "span_containing", as
+// described in:
+// https://opensearch.org/docs/latest/query-dsl/span/span-containing/
+type SpanContainingQuery struct {
+	apiName string
+	big     SpanQuery
+	little  SpanQuery
+	boost   *float32
+}
+
+// SpanContaining creates a new query of type "span_containing", matching the
+// spans of big that contain a span of little.
+func SpanContaining(big, little SpanQuery) *SpanContainingQuery {
+	return &SpanContainingQuery{
+		apiName: "span_containing",
+		big:     big,
+		little:  little,
+	}
+}
+
+// SpanWithin creates a new query of type "span_within", as described in
+// https://opensearch.org/docs/latest/query-dsl/span/span-within/, matching
+// the spans of little that are contained in a span of big.
+func SpanWithin(big, little SpanQuery) *SpanContainingQuery {
+	return &SpanContainingQuery{
+		apiName: "span_within",
+		big:     big,
+		little:  little,
+	}
+}
+
+func (q *SpanContainingQuery) spanQuery() {}
+
+// Boost sets the boost value of the query.
+func (q *SpanContainingQuery) Boost(b float32) *SpanContainingQuery {
+	q.boost = &b
+	return q
+}
+
+// Map returns a map representation of the query, thus implementing the
+// Mappable interface.
+func (q *SpanContainingQuery) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"big":    q.big.Map(),
+		"little": q.little.Map(),
+	}
+	if q.boost != nil {
+		innerMap["boost"] = *q.boost
+	}
+
+	return map[string]interface{}{
+		q.apiName: innerMap,
+	}
+}
+
+//----------------------------------------------------------------------------//
+
+// FieldMaskingSpanQuery represents a query of type "field_masking_span", as
+// described in:
+// https://opensearch.org/docs/latest/query-dsl/span/span-field-masking/
+// It makes a span query on one field appear to be on another, so that spans
+// of different fields (e.g. a field and its stemmed sub-field) can be
+// combined in span_near or span_or queries.
+type FieldMaskingSpanQuery struct {
+	query SpanQuery
+	field string
+	boost *float32
+}
+
+// FieldMaskingSpan creates a new query of type "field_masking_span", masking
+// the provided span query as being on the provided field.
+func FieldMaskingSpan(query SpanQuery, field string) *FieldMaskingSpanQuery {
+	return &FieldMaskingSpanQuery{
+		query: query,
+		field: field,
+	}
+}
+
+func (q *FieldMaskingSpanQuery) spanQuery() {}
+
+// Boost sets the boost value of the query.
+func (q *FieldMaskingSpanQuery) Boost(b float32) *FieldMaskingSpanQuery {
+	q.boost = &b
+	return q
+}
+
+// Map returns a map representation of the query, thus implementing the
+// Mappable interface.
+func (q *FieldMaskingSpanQuery) Map() map[string]interface{} {
+	innerMap := map[string]interface{}{
+		"query": q.query.Map(),
+		"field": q.field,
+	}
+	if q.boost != nil {
+		innerMap["boost"] = *q.boost
+	}
+
+	return map[string]interface{}{
+		"field_masking_span": innerMap,
+	}
+}
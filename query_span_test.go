@@ -0,0 +1,169 @@
+package osquery
+
+import (
+	"testing"
+)
+
+func TestSpanQueries(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"span_term",
+			SpanTerm("text", "contract").Boost(2),
+			map[string]interface{}{
+				"span_term": map[string]interface{}{
+					"text": map[string]interface{}{
+						"value": "contract",
+						"boost": 2,
+					},
+				},
+			},
+		},
+		{
+			"span_near",
+			SpanNear(SpanTerm("text", "breach"), SpanTerm("text", "contract")).
+				Slop(3).
+				InOrder(true),
+			map[string]interface{}{
+				"span_near": map[string]interface{}{
+					"clauses": []map[string]interface{}{
+						{"span_term": map[string]interface{}{"text": map[string]interface{}{"value": "breach"}}},
+						{"span_term": map[string]interface{}{"text": map[string]interface{}{"value": "contract"}}},
+					},
+					"slop":     3,
+					"in_order": true,
+				},
+			},
+		},
+		{
+			"span_or",
+			SpanOr(SpanTerm("text", "tenant")).Clauses(SpanTerm("text", "lessee")),
+			map[string]interface{}{
+				"span_or": map[string]interface{}{
+					"clauses": []map[string]interface{}{
+						{"span_term": map[string]interface{}{"text": map[string]interface{}{"value": "tenant"}}},
+						{"span_term": map[string]interface{}{"text": map[string]interface{}{"value": "lessee"}}},
+					},
+				},
+			},
+		},
+		{
+			"span_not",
+			SpanNot(
+				SpanNear(SpanTerm("text", "quick"), SpanTerm("text", "fox")).Slop(1),
+				SpanTerm("text", "red"),
+			).Pre(1).Post(2),
+			map[string]interface{}{
+				"span_not": map[string]interface{}{
+					"include": map[string]interface{}{
+						"span_near": map[string]interface{}{
+							"clauses": []map[string]interface{}{
+								{"span_term": map[string]interface{}{"text": map[string]interface{}{"value": "quick"}}},
+								{"span_term": map[string]interface{}{"text": map[string]interface{}{"value": "fox"}}},
+							},
+							"slop": 1,
+						},
+					},
+					"exclude": map[string]interface{}{
+						"span_term": map[string]interface{}{"text": map[string]interface{}{"value": "red"}},
+					},
+					"pre":  1,
+					"post": 2,
+				},
+			},
+		},
+		{
+			"span_first with span_multi",
+			SpanFirst(SpanMulti(Prefix("text", "indemn")), 20),
+			map[string]interface{}{
+				"span_first": map[string]interface{}{
+					"match": map[string]interface{}{
+						"span_multi": map[string]interface{}{
+							"match": map[string]interface{}{
+								"prefix": map[string]interface{}{
+									"text": map[string]interface{}{"value": "indemn"},
+								},
+							},
+						},
+					},
+					"end": 20,
+				},
+			},
+		},
+		{
+			"span_containing and span_within",
+			SpanOr(
+				SpanContaining(
+					SpanNear(SpanTerm("text", "foo"), SpanTerm("text", "bar")).Slop(5),
+					SpanTerm("text", "baz"),
+				),
+				SpanWithin(
+					SpanNear(SpanTerm("text", "foo"), SpanTerm("text", "bar")).Slop(5),
+					SpanTerm("text", "qux"),
+				).Boost(1.5),
+			),
+			map[string]interface{}{
+				"span_or": map[string]interface{}{
+					"clauses": []map[string]interface{}{
+						{
+							"span_containing": map[string]interface{}{
+								"big": map[string]interface{}{
+									"span_near": map[string]interface{}{
+										"clauses": []map[string]interface{}{
+											{"span_term": map[string]interface{}{"text": map[string]interface{}{"value": "foo"}}},
+											{"span_term": map[string]interface{}{"text": map[string]interface{}{"value": "bar"}}},
+										},
+										"slop": 5,
+									},
+								},
+								"little": map[string]interface{}{
+									"span_term": map[string]interface{}{"text": map[string]interface{}{"value": "baz"}},
+								},
+							},
+						},
+						{
+							"span_within": map[string]interface{}{
+								"big": map[string]interface{}{
+									"span_near": map[string]interface{}{
+										"clauses": []map[string]interface{}{
+											{"span_term": map[string]interface{}{"text": map[string]interface{}{"value": "foo"}}},
+											{"span_term": map[string]interface{}{"text": map[string]interface{}{"value": "bar"}}},
+										},
+										"slop": 5,
+									},
+								},
+								"little": map[string]interface{}{
+									"span_term": map[string]interface{}{"text": map[string]interface{}{"value": "qux"}},
+								},
+								"boost": 1.5,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"field_masking_span",
+			SpanNear(
+				SpanTerm("text", "quick"),
+				FieldMaskingSpan(SpanTerm("text.stems", "fox"), "text"),
+			).Slop(5).InOrder(false),
+			map[string]interface{}{
+				"span_near": map[string]interface{}{
+					"clauses": []map[string]interface{}{
+						{"span_term": map[string]interface{}{"text": map[string]interface{}{"value": "quick"}}},
+						{
+							"field_masking_span": map[string]interface{}{
+								"query": map[string]interface{}{
+									"span_term": map[string]interface{}{"text.stems": map[string]interface{}{"value": "fox"}},
+								},
+								"field": "text",
+							},
+						},
+					},
+					"slop":     5,
+					"in_order": false,
+				},
+			},
+		},
+	})
+}
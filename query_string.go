@@ -0,0 +1,348 @@
+package osquery
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/structs"
+)
+
+// boostedField returns the name of a field with a boost, as accepted by the
+// "fields" parameter of full-text queries (e.g. "title^3").
+func boostedField(field string, boost float32) string {
+	return fmt.Sprintf("%s^%g", field, boost)
+}
+
+// QueryStringQuery represents a query of type "query_string", as described in:
+// https://opensearch.org/docs/latest/query-dsl/full-text/query-string/
+// The query string is parsed using the Lucene query syntax, and fails on
+// invalid syntax; see SimpleQueryString for a more lenient alternative.
+type QueryStringQuery struct {
+	params queryStringParams
+}
+
+type queryStringParams struct {
+	Qry                 string         `structs:"query"`
+	DefaultField        string         `structs:"default_field,omitempty"`
+	Fields              []string       `structs:"fields,omitempty"`
+	DefaultOp           *MatchOperator `structs:"default_operator,string,omitempty"`
+	Anl                 string         `structs:"analyzer,omitempty"`
+	QuoteAnl            string         `structs:"quote_analyzer,omitempty"`
+	QuoteFieldSuffix    string         `structs:"quote_field_suffix,omitempty"`
+	AllowLeadingWC      *bool          `structs:"allow_leading_wildcard,omitempty"`
+	AnalyzeWC           *bool          `structs:"analyze_wildcard,omitempty"`
+	Fuzz                string         `structs:"fuzziness,omitempty"`
+	FuzzyMaxExp         uint16         `structs:"fuzzy_max_expansions,omitempty"`
+	FuzzyPrefLen        uint16         `structs:"fuzzy_prefix_length,omitempty"`
+	FuzzyTranspositions *bool          `structs:"fuzzy_transpositions,omitempty"`
+	PhraseSlp           uint16         `structs:"phrase_slop,omitempty"`
+	Lent                *bool          `structs:"lenient,omitempty"`
+	MinMatch            string         `structs:"minimum_should_match,omitempty"`
+	TimeZone            string         `structs:"time_zone,omitempty"`
+	Boost               float32        `structs:"boost,omitempty"`
+	Name                string         `structs:"_name,omitempty"`
+}
+
+// QueryString creates a new query of type "query_string" with the provided
+// query string.
+func QueryString(query string) *QueryStringQuery {
+	return &QueryStringQuery{
+		params: queryStringParams{Qry: query},
+	}
+}
+
+// Map returns a map representation of the query; implementing the
+// Mappable interface.
+func (q *QueryStringQuery) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"query_string": structs.Map(q.params),
+	}
+}
+
+// DefaultField sets the field searched when the query string doesn't specify
+// any.
+func (q *QueryStringQuery) DefaultField(field string) *QueryStringQuery {
+	q.params.DefaultField = field
+	return q
+}
+
+// Fields sets the fields searched when the query string doesn't specify any.
+// Fields may include a boost, as in "title^3".
+func (q *QueryStringQuery) Fields(fields ...string) *QueryStringQuery {
+	q.params.Fields = append(q.params.Fields, fields...)
+	return q
+}
+
+// BoostedField adds a field to search, with the provided boost.
+func (q *QueryStringQuery) BoostedField(field string, boost float32) *QueryStringQuery {
+	q.params.Fields = append(q.params.Fields, boostedField(field, boost))
+	return q
+}
+
+// DefaultOperator sets the boolean logic used to combine terms without an
+// explicit operator.
+func (q *QueryStringQuery) DefaultOperator(op MatchOperator) *QueryStringQuery {
+	q.params.DefaultOp = &op
+	return q
+}
+
+// Analyzer sets the analyzer used to convert the query string into tokens.
+func (q *QueryStringQuery) Analyzer(a string) *QueryStringQuery {
+	q.params.Anl = a
+	return q
+}
+
+// QuoteAnalyzer sets the analyzer used for quoted text in the query string.
+func (q *QueryStringQuery) QuoteAnalyzer(a string) *QueryStringQuery {
+	q.params.QuoteAnl = a
+	return q
+}
+
+// QuoteFieldSuffix sets a suffix appended to field names for quoted text, for
+// example to search an unstemmed sub-field for exact phrases.
+func (q *QueryStringQuery) QuoteFieldSuffix(s string) *QueryStringQuery {
+	q.params.QuoteFieldSuffix = s
+	return q
+}
+
+// AllowLeadingWildcard sets whether "*" and "?" are allowed as the first
+// character of a term.
+func (q *QueryStringQuery) AllowLeadingWildcard(b bool) *QueryStringQuery {
+	q.params.AllowLeadingWC = &b
+	return q
+}
+
+// AnalyzeWildcard sets whether wildcard terms are analyzed.
+func (q *QueryStringQuery) AnalyzeWildcard(b bool) *QueryStringQuery {
+	q.params.AnalyzeWC = &b
+	return q
+}
+
+// Fuzziness set the maximum edit distance allowed for fuzzy terms.
+func (q *QueryStringQuery) Fuzziness(f string) *QueryStringQuery {
+	q.params.Fuzz = f
+	return q
+}
+
+// FuzzyMaxExpansions sets the maximum number of terms to which fuzzy terms
+// expand.
+func (q *QueryStringQuery) FuzzyMaxExpansions(e uint16) *QueryStringQuery {
+	q.params.FuzzyMaxExp = e
+	return q
+}
+
+// FuzzyPrefixLength sets the number of beginning characters left unchanged
+// for fuzzy matching.
+func (q *QueryStringQuery) FuzzyPrefixLength(l uint16) *QueryStringQuery {
+	q.params.FuzzyPrefLen = l
+	return q
+}
+
+// FuzzyTranspositions sets whether edits for fuzzy matching include
+// transpositions of two adjacent characters.
+func (q *QueryStringQuery) FuzzyTranspositions(b bool) *QueryStringQuery {
+	q.params.FuzzyTranspositions = &b
+	return q
+}
+
+// PhraseSlop sets the maximum number of positions allowed between matching
+// tokens of phrases.
+func (q *QueryStringQuery) PhraseSlop(n uint16) *QueryStringQuery {
+	q.params.PhraseSlp = n
+	return q
+}
+
+// Lenient sets whether format-based errors should be ignored.
+func (q *QueryStringQuery) Lenient(b bool) *QueryStringQuery {
+	q.params.Lent = &b
+	return q
+}
+
+// MinimumShouldMatch sets the minimum number of clauses that must match for a
+// document to be returned.
+func (q *QueryStringQuery) MinimumShouldMatch(s string) *QueryStringQuery {
+	q.params.MinMatch = s
+	return q
+}
+
+// TimeZone sets the time zone used to convert dates in the query string.
+func (q *QueryStringQuery) TimeZone(zone string) *QueryStringQuery {
+	q.params.TimeZone = zone
+	return q
+}
+
+// Boost sets the boost value for the query.
+func (q *QueryStringQuery) Boost(b float32) *QueryStringQuery {
+	q.params.Boost = b
+	return q
+}
+
+// Name sets the name of the query that is returned in matched_queries in response
+// if document matches the query.
+func (q *QueryStringQuery) Name(n string) *QueryStringQuery {
+	q.params.Name = n
+	return q
+}
+
+//----------------------------------------------------------------------------//
+
+// SimpleQueryStringQuery represents a query of type "simple_query_string", as
+// described in:
+// https://opensearch.org/docs/latest/query-dsl/full-text/simple-query-string/
+// Unlike QueryString, it uses a simpler syntax and ignores invalid parts of
+// the query string rather than failing, which makes it suitable for user
+// input.
+type SimpleQueryStringQuery struct {
+	params simpleQueryStringParams
+}
+
+type simpleQueryStringParams struct {
+	Qry                 string         `structs:"query"`
+	Fields              []string       `structs:"fields,omitempty"`
+	DefaultOp           *MatchOperator `structs:"default_operator,string,omitempty"`
+	Anl                 string         `structs:"analyzer,omitempty"`
+	Flags               string         `structs:"flags,omitempty"`
+	QuoteFieldSuffix    string         `structs:"quote_field_suffix,omitempty"`
+	AnalyzeWC           *bool          `structs:"analyze_wildcard,omitempty"`
+	FuzzyMaxExp         uint16         `structs:"fuzzy_max_expansions,omitempty"`
+	FuzzyPrefLen        uint16         `structs:"fuzzy_prefix_length,omitempty"`
+	FuzzyTranspositions *bool          `structs:"fuzzy_transpositions,omitempty"`
+	Lent                *bool          `structs:"lenient,omitempty"`
+	MinMatch            string         `structs:"minimum_should_match,omitempty"`
+	Boost               float32        `structs:"boost,omitempty"`
+	Name                string         `structs:"_name,omitempty"`
+}
+
+// SimpleQueryString creates a new query of type "simple_query_string" with
+// the provided query string.
+func SimpleQueryString(query string) *SimpleQueryStringQuery {
+	return &SimpleQueryStringQuery{
+		params: simpleQueryStringParams{Qry: query},
+	}
+}
+
+// Map returns a map representation of the query; implementing the
+// Mappable interface.
+func (q *SimpleQueryStringQuery) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"simple_query_string": structs.Map(q.params),
+	}
+}
+
+// Fields sets the fields to search. Fields may include a boost, as in
+// "title^3".
+func (q *SimpleQueryStringQuery) Fields(fields ...string) *SimpleQueryStringQuery {
+	q.params.Fields = append(q.params.Fields, fields...)
+	return q
+}
+
+// BoostedField adds a field to search, with the provided boost.
+func (q *SimpleQueryStringQuery) BoostedField(field string, boost float32) *SimpleQueryStringQuery {
+	q.params.Fields = append(q.params.Fields, boostedField(field, boost))
+	return q
+}
+
+// DefaultOperator sets the boolean logic used to combine terms without an
+// explicit operator.
+func (q *SimpleQueryStringQuery) DefaultOperator(op MatchOperator) *SimpleQueryStringQuery {
+	q.params.DefaultOp = &op
+	return q
+}
+
+// Analyzer sets the analyzer used to convert the query string into tokens.
+func (q *SimpleQueryStringQuery) Analyzer(a string) *SimpleQueryStringQuery {
+	q.params.Anl = a
+	return q
+}
+
+// Flags sets the operators of the syntax that are enabled. By default, all of
+// them are.
+func (q *SimpleQueryStringQuery) Flags(flags ...SimpleQueryStringFlag) *SimpleQueryStringQuery {
+	names := make([]string, len(flags))
+	for i, flag := range flags {
+		names[i] = string(flag)
+	}
+	q.params.Flags = strings.Join(names, "|")
+	return q
+}
+
+// QuoteFieldSuffix sets a suffix appended to field names for quoted text, for
+// example to search an unstemmed sub-field for exact phrases.
+func (q *SimpleQueryStringQuery) QuoteFieldSuffix(s string) *SimpleQueryStringQuery {
+	q.params.QuoteFieldSuffix = s
+	return q
+}
+
+// AnalyzeWildcard sets whether prefix terms are analyzed.
+func (q *SimpleQueryStringQuery) AnalyzeWildcard(b bool) *SimpleQueryStringQuery {
+	q.params.AnalyzeWC = &b
+	return q
+}
+
+// FuzzyMaxExpansions sets the maximum number of terms to which fuzzy terms
+// expand.
+func (q *SimpleQueryStringQuery) FuzzyMaxExpansions(e uint16) *SimpleQueryStringQuery {
+	q.params.FuzzyMaxExp = e
+	return q
+}
+
+// FuzzyPrefixLength sets the number of beginning characters left unchanged
+// for fuzzy matching.
+func (q *SimpleQueryStringQuery) FuzzyPrefixLength(l uint16) *SimpleQueryStringQuery {
+	q.params.FuzzyPrefLen = l
+	return q
+}
+
+// FuzzyTranspositions sets whether edits for fuzzy matching include
+// transpositions of two adjacent characters.
+func (q *SimpleQueryStringQuery) FuzzyTranspositions(b bool) *SimpleQueryStringQuery {
+	q.params.FuzzyTranspositions = &b
+	return q
+}
+
+// Lenient sets whether format-based errors should be ignored.
+func (q *SimpleQueryStringQuery) Lenient(b bool) *SimpleQueryStringQuery {
+	q.params.Lent = &b
+	return q
+}
+
+// MinimumShouldMatch sets the minimum number of clauses that must match for a
+// document to be returned.
+func (q *SimpleQueryStringQuery) MinimumShouldMatch(s string) *SimpleQueryStringQuery {
+	q.params.MinMatch = s
+	return q
+}
+
+// Boost sets the boost value for the query.
+func (q *SimpleQueryStringQuery) Boost(b float32) *SimpleQueryStringQuery {
+	q.params.Boost = b
+	return q
+}
+
+// Name sets the name of the query that is returned in matched_queries in response
+// if document matches the query.
+func (q *SimpleQueryStringQuery) Name(n string) *SimpleQueryStringQuery {
+	q.params.Name = n
+	return q
+}
+
+// SimpleQueryStringFlag is an enumeration type representing the operators of
+// the simple_query_string syntax that can be enabled via the "flags"
+// parameter.
+type SimpleQueryStringFlag string
+
+const (
+	SimpleQueryFlagAll        SimpleQueryStringFlag = "ALL"
+	SimpleQueryFlagNone       SimpleQueryStringFlag = "NONE"
+	SimpleQueryFlagAnd        SimpleQueryStringFlag = "AND"
+	SimpleQueryFlagOr         SimpleQueryStringFlag = "OR"
+	SimpleQueryFlagNot        SimpleQueryStringFlag = "NOT"
+	SimpleQueryFlagPrefix     SimpleQueryStringFlag = "PREFIX"
+	SimpleQueryFlagPhrase     SimpleQueryStringFlag = "PHRASE"
+	SimpleQueryFlagPrecedence SimpleQueryStringFlag = "PRECEDENCE"
+	SimpleQueryFlagEscape     SimpleQueryStringFlag = "ESCAPE"
+	SimpleQueryFlagWhitespace SimpleQueryStringFlag = "WHITESPACE"
+	SimpleQueryFlagFuzzy      SimpleQueryStringFlag = "FUZZY"
+	SimpleQueryFlagNear       SimpleQueryStringFlag = "NEAR"
+	SimpleQueryFlagSlop       SimpleQueryStringFlag = "SLOP"
+)
@@ -0,0 +1,90 @@
+package osquery
+
+import (
+	"testing"
+)
+
+func TestQueryString(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"query_string with only a query",
+			QueryString("(new york city) OR (big apple)"),
+			map[string]interface{}{
+				"query_string": map[string]interface{}{
+					"query": "(new york city) OR (big apple)",
+				},
+			},
+		},
+		{
+			"query_string with default field and options",
+			QueryString("status:active AND te*").
+				DefaultField("content").
+				DefaultOperator(OperatorAnd).
+				Analyzer("standard").
+				AllowLeadingWildcard(false).
+				AnalyzeWildcard(true).
+				Fuzziness("AUTO").
+				FuzzyMaxExpansions(20).
+				PhraseSlop(2).
+				QuoteFieldSuffix(".exact").
+				Lenient(true).
+				TimeZone("+01:00").
+				Name("search_box"),
+			map[string]interface{}{
+				"query_string": map[string]interface{}{
+					"query":                  "status:active AND te*",
+					"default_field":          "content",
+					"default_operator":       "AND",
+					"analyzer":               "standard",
+					"allow_leading_wildcard": false,
+					"analyze_wildcard":       true,
+					"fuzziness":              "AUTO",
+					"fuzzy_max_expansions":   20,
+					"phrase_slop":            2,
+					"quote_field_suffix":     ".exact",
+					"lenient":                true,
+					"time_zone":              "+01:00",
+					"_name":                  "search_box",
+				},
+			},
+		},
+		{
+			"query_string with boosted fields",
+			QueryString("this AND that").
+				Fields("title^3", "summary").
+				BoostedField("body", 0.5).
+				DefaultOperator(OperatorOr),
+			map[string]interface{}{
+				"query_string": map[string]interface{}{
+					"query":            "this AND that",
+					"fields":           []string{"title^3", "summary", "body^0.5"},
+					"default_operator": "OR",
+				},
+			},
+		},
+		{
+			"simple_query_string",
+			SimpleQueryString(`"fried eggs" +(eggplant | potato) -frittata`).
+				Fields("title^5").
+				BoostedField("body", 2).
+				DefaultOperator(OperatorAnd).
+				Flags(SimpleQueryFlagOr, SimpleQueryFlagAnd, SimpleQueryFlagPrefix).
+				QuoteFieldSuffix(".exact").
+				FuzzyPrefixLength(1).
+				Lenient(true).
+				MinimumShouldMatch("2"),
+			map[string]interface{}{
+				"simple_query_string": map[string]interface{}{
+					"query":                `"fried eggs" +(eggplant | potato) -frittata`,
+					"fields":               []string{"title^5", "body^2"},
+					"default_operator":     "AND",
+					"flags":                "OR|AND|PREFIX",
+					"quote_field_suffix":   ".exact",
+					"fuzzy_prefix_length":  1,
+					"lenient":              true,
+					"minimum_should_match": "2",
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,380 @@
+package osquery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// ReindexRequest represents a request to OpenSearch's Reindex API, copying
+// documents from one or more source indices to a destination index, as
+// described in:
+// https://opensearch.org/docs/latest/api-reference/document-apis/reindex/
+type ReindexRequest struct {
+	source            *ReindexSource
+	dest              *ReindexDest
+	script            *ScriptField
+	conflicts         Conflicts
+	maxDocs           *uint64
+	slices            interface{}
+	refresh           *bool
+	waitForCompletion *bool
+}
+
+// Reindex creates a new ReindexRequest object, to be filled via method
+// chaining.
+func Reindex() *ReindexRequest {
+	return &ReindexRequest{}
+}
+
+// Source sets the source of the documents to reindex.
+func (req *ReindexRequest) Source(source *ReindexSource) *ReindexRequest {
+	req.source = source
+	return req
+}
+
+// Dest sets the destination of the reindexed documents.
+func (req *ReindexRequest) Dest(dest *ReindexDest) *ReindexRequest {
+	req.dest = dest
+	return req
+}
+
+// Script sets a script transforming documents before they are indexed into
+// the destination.
+func (req *ReindexRequest) Script(script *ScriptField) *ReindexRequest {
+	req.script = script
+	return req
+}
+
+// Conflicts sets how version conflicts are handled.
+func (req *ReindexRequest) Conflicts(conflicts Conflicts) *ReindexRequest {
+	req.conflicts = conflicts
+	return req
+}
+
+// MaxDocs sets the maximum number of documents to reindex.
+func (req *ReindexRequest) MaxDocs(maxDocs uint64) *ReindexRequest {
+	req.maxDocs = &maxDocs
+	return req
+}
+
+// Slices sets the number of slices the request is divided into, to be
+// processed in parallel.
+func (req *ReindexRequest) Slices(slices uint) *ReindexRequest {
+	req.slices = int(slices)
+	return req
+}
+
+// SlicesAuto lets OpenSearch choose the number of slices the request is
+// divided into.
+func (req *ReindexRequest) SlicesAuto() *ReindexRequest {
+	req.slices = slicesAuto
+	return req
+}
+
+// Refresh sets whether the destination index is refreshed once the request
+// completes.
+func (req *ReindexRequest) Refresh(b bool) *ReindexRequest {
+	req.refresh = &b
+	return req
+}
+
+// WaitForCompletion sets whether the request blocks until the operation
+// completes. When false, OpenSearch runs the operation as a task and the
+// response only holds the task's ID.
+func (req *ReindexRequest) WaitForCompletion(b bool) *ReindexRequest {
+	req.waitForCompletion = &b
+	return req
+}
+
+// Map returns a map representation of the request's body, thus implementing
+// the Mappable interface.
+func (req *ReindexRequest) Map() map[string]interface{} {
+	m := make(map[string]interface{})
+	if req.source != nil {
+		m["source"] = req.source.Map()
+	}
+	if req.dest != nil {
+		m["dest"] = req.dest.Map()
+	}
+	if req.script != nil {
+		m["script"] = req.script.Map()["script"]
+	}
+	if req.conflicts != "" {
+		m["conflicts"] = req.conflicts
+	}
+	if req.maxDocs != nil {
+		m["max_docs"] = *req.maxDocs
+	}
+
+	return m
+}
+
+// Run executes the request using the provided OpenSearch client. The options'
+// Params, if provided, must be a *opensearchapi.ReindexParams; values set on
+// the request itself take precedence over them. Options.Indices is ignored,
+// as indices are set via the source and destination.
+func (req *ReindexRequest) Run(
+	ctx context.Context,
+	client *opensearch.Client,
+	options *Options,
+) (*BulkByScrollResponse, error) {
+	if req.source == nil || req.dest == nil {
+		return nil, fmt.Errorf("reindex request requires a source and a destination")
+	}
+	if len(req.source.indices) == 0 {
+		return nil, fmt.Errorf("reindex request requires at least one source index")
+	}
+
+	body, err := json.Marshal(req.Map())
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize request body: %w", err)
+	}
+
+	reindexReq := opensearchapi.ReindexReq{
+		Body: bytes.NewReader(body),
+	}
+
+	// Apply additional options if provided
+	err = ApplyOptions(&reindexReq, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.slices != nil {
+		reindexReq.Params.Slices = req.slices
+	}
+	if req.refresh != nil {
+		reindexReq.Params.Refresh = req.refresh
+	}
+	if req.waitForCompletion != nil {
+		reindexReq.Params.WaitForCompletion = req.waitForCompletion
+	}
+
+	var reindexResp BulkByScrollResponse
+	if err := execute(ctx, client, reindexReq, &reindexResp); err != nil {
+		return nil, fmt.Errorf("reindex request failed: %w", err)
+	}
+
+	return &reindexResp, nil
+}
+
+//----------------------------------------------------------------------------//
+
+// ReindexSource represents the "source" option of a reindex request.
+type ReindexSource struct {
+	indices []string
+	query   Mappable
+	source  Source
+	size    *uint64
+	remote  *ReindexRemote
+}
+
+// ReindexFrom creates a new ReindexSource reading from the provided indices.
+func ReindexFrom(indices ...string) *ReindexSource {
+	return &ReindexSource{
+		indices: indices,
+	}
+}
+
+// Query sets the query selecting the documents to reindex.
+func (src *ReindexSource) Query(q Mappable) *ReindexSource {
+	src.query = q
+	return src
+}
+
+// SourceIncludes sets the fields of the documents to reindex.
+func (src *ReindexSource) SourceIncludes(keys ...string) *ReindexSource {
+	src.source.includes = keys
+	return src
+}
+
+// SourceExcludes sets the fields of the documents not to reindex.
+func (src *ReindexSource) SourceExcludes(keys ...string) *ReindexSource {
+	src.source.excludes = keys
+	return src
+}
+
+// Size sets the number of documents read per batch.
+func (src *ReindexSource) Size(size uint64) *ReindexSource {
+	src.size = &size
+	return src
+}
+
+// Remote makes the documents be read from a remote cluster.
+func (src *ReindexSource) Remote(remote *ReindexRemote) *ReindexSource {
+	src.remote = remote
+	return src
+}
+
+// Map returns a map representation of the source, thus implementing the
+// Mappable interface.
+func (src *ReindexSource) Map() map[string]interface{} {
+	m := map[string]interface{}{
+		"index": src.indices,
+	}
+	if src.query != nil {
+		m["query"] = src.query.Map()
+	}
+	source := src.source.Map()
+	if len(source) > 0 {
+		m["_source"] = source
+	}
+	if src.size != nil {
+		m["size"] = *src.size
+	}
+	if src.remote != nil {
+		m["remote"] = src.remote.Map()
+	}
+
+	return m
+}
+
+//----------------------------------------------------------------------------//
+
+// ReindexRemote represents the "remote" option of a reindex source, used to
+// reindex documents from another cluster. The remote host must be allowed
+// by the destination cluster's "reindex.remote.allowlist" setting.
+type ReindexRemote struct {
+	host           string
+	username       string
+	password       string
+	headers        map[string]string
+	socketTimeout  *time.Duration
+	connectTimeout *time.Duration
+}
+
+// RemoteHost creates a new ReindexRemote reading from the provided host,
+// e.g. "https://other-cluster:9200".
+func RemoteHost(host string) *ReindexRemote {
+	return &ReindexRemote{
+		host: host,
+	}
+}
+
+// Username sets the username used to authenticate with the remote cluster.
+func (remote *ReindexRemote) Username(username string) *ReindexRemote {
+	remote.username = username
+	return remote
+}
+
+// Password sets the password used to authenticate with the remote cluster.
+func (remote *ReindexRemote) Password(password string) *ReindexRemote {
+	remote.password = password
+	return remote
+}
+
+// Headers sets HTTP headers sent to the remote cluster.
+func (remote *ReindexRemote) Headers(headers map[string]string) *ReindexRemote {
+	remote.headers = headers
+	return remote
+}
+
+// SocketTimeout sets the timeout for reading from the remote cluster.
+func (remote *ReindexRemote) SocketTimeout(timeout time.Duration) *ReindexRemote {
+	remote.socketTimeout = &timeout
+	return remote
+}
+
+// ConnectTimeout sets the timeout for connecting to the remote cluster.
+func (remote *ReindexRemote) ConnectTimeout(timeout time.Duration) *ReindexRemote {
+	remote.connectTimeout = &timeout
+	return remote
+}
+
+// Map returns a map representation of the remote, thus implementing the
+// Mappable interface.
+func (remote *ReindexRemote) Map() map[string]interface{} {
+	m := map[string]interface{}{
+		"host": remote.host,
+	}
+	if remote.username != "" {
+		m["username"] = remote.username
+	}
+	if remote.password != "" {
+		m["password"] = remote.password
+	}
+	if len(remote.headers) > 0 {
+		m["headers"] = remote.headers
+	}
+	if remote.socketTimeout != nil {
+		m["socket_timeout"] = formatDuration(*remote.socketTimeout)
+	}
+	if remote.connectTimeout != nil {
+		m["connect_timeout"] = formatDuration(*remote.connectTimeout)
+	}
+
+	return m
+}
+
+//----------------------------------------------------------------------------//
+
+// OpType is an enumeration type for the "op_type" option of a reindex
+// destination.
+type OpType string
+
+const (
+	// OpTypeIndex indexes documents, replacing those that already exist.
+	OpTypeIndex OpType = "index"
+
+	// OpTypeCreate only indexes documents missing from the destination.
+	OpTypeCreate OpType = "create"
+)
+
+// ReindexDest represents the "dest" option of a reindex request.
+type ReindexDest struct {
+	index       string
+	opType      OpType
+	pipeline    string
+	versionType string
+}
+
+// ReindexTo creates a new ReindexDest writing to the provided index.
+func ReindexTo(index string) *ReindexDest {
+	return &ReindexDest{
+		index: index,
+	}
+}
+
+// OpType sets how documents are written to the destination.
+func (dest *ReindexDest) OpType(opType OpType) *ReindexDest {
+	dest.opType = opType
+	return dest
+}
+
+// Pipeline sets the ingest pipeline documents go through.
+func (dest *ReindexDest) Pipeline(pipeline string) *ReindexDest {
+	dest.pipeline = pipeline
+	return dest
+}
+
+// VersionType sets the versioning used when writing documents, e.g.
+// "internal" or "external".
+func (dest *ReindexDest) VersionType(versionType string) *ReindexDest {
+	dest.versionType = versionType
+	return dest
+}
+
+// Map returns a map representation of the destination, thus implementing the
+// Mappable interface.
+func (dest *ReindexDest) Map() map[string]interface{} {
+	m := map[string]interface{}{
+		"index": dest.index,
+	}
+	if dest.opType != "" {
+		m["op_type"] = dest.opType
+	}
+	if dest.pipeline != "" {
+		m["pipeline"] = dest.pipeline
+	}
+	if dest.versionType != "" {
+		m["version_type"] = dest.versionType
+	}
+
+	return m
+}
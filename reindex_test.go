@@ -0,0 +1,135 @@
+package osquery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jgroeneveld/trial/assert"
+)
+
+func TestReindexMaps(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"simple reindex",
+			Reindex().
+				Source(ReindexFrom("books")).
+				Dest(ReindexTo("books-v2")),
+			map[string]interface{}{
+				"source": map[string]interface{}{
+					"index": []string{"books"},
+				},
+				"dest": map[string]interface{}{
+					"index": "books-v2",
+				},
+			},
+		},
+		{
+			"reindex with all options",
+			Reindex().
+				Source(
+					ReindexFrom("books", "magazines").
+						Query(Range("year").Gte(2000)).
+						SourceIncludes("title", "year").
+						SourceExcludes("draft").
+						Size(500),
+				).
+				Dest(
+					ReindexTo("publications").
+						OpType(OpTypeCreate).
+						Pipeline("enrich").
+						VersionType("external"),
+				).
+				Script(Script("").Source("ctx._source.kind = 'book'")).
+				Conflicts(ConflictsProceed).
+				MaxDocs(1000),
+			map[string]interface{}{
+				"source": map[string]interface{}{
+					"index": []string{"books", "magazines"},
+					"query": map[string]interface{}{
+						"range": map[string]interface{}{
+							"year": map[string]interface{}{
+								"gte": 2000,
+							},
+						},
+					},
+					"_source": map[string]interface{}{
+						"includes": []string{"title", "year"},
+						"excludes": []string{"draft"},
+					},
+					"size": 500,
+				},
+				"dest": map[string]interface{}{
+					"index":        "publications",
+					"op_type":      "create",
+					"pipeline":     "enrich",
+					"version_type": "external",
+				},
+				"script": map[string]interface{}{
+					"source": "ctx._source.kind = 'book'",
+				},
+				"conflicts": "proceed",
+				"max_docs":  1000,
+			},
+		},
+		{
+			"reindex from remote",
+			Reindex().
+				Source(
+					ReindexFrom("books").
+						Remote(
+							RemoteHost("https://other:9200").
+								Username("user").
+								Password("pass").
+								Headers(map[string]string{"X-Tenant": "a"}).
+								SocketTimeout(time.Minute).
+								ConnectTimeout(10 * time.Second),
+						),
+				).
+				Dest(ReindexTo("books")),
+			map[string]interface{}{
+				"source": map[string]interface{}{
+					"index": []string{"books"},
+					"remote": map[string]interface{}{
+						"host":            "https://other:9200",
+						"username":        "user",
+						"password":        "pass",
+						"headers":         map[string]string{"X-Tenant": "a"},
+						"socket_timeout":  "60000ms",
+						"connect_timeout": "10000ms",
+					},
+				},
+				"dest": map[string]interface{}{
+					"index": "books",
+				},
+			},
+		},
+	})
+}
+
+func TestReindexRun(t *testing.T) {
+	client, requests := newTestClient(t, func(int, recordedRequest) (int, string) {
+		return 200, `{"task":"node-1:7"}`
+	})
+	ctx := context.Background()
+
+	res, err := Reindex().
+		Source(ReindexFrom("books")).
+		Dest(ReindexTo("books-v2")).
+		Slices(2).
+		WaitForCompletion(false).
+		Run(ctx, client, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "node-1:7", res.Task)
+
+	reqs := requests()
+	assert.Equal(t, "/_reindex", reqs[0].Path)
+	assert.Equal(t, "slices=2&wait_for_completion=false", reqs[0].Query)
+
+	_, err = Reindex().Source(ReindexFrom("books")).Run(ctx, client, nil)
+	assert.NotNil(t, err)
+
+	_, err = Reindex().Source(ReindexFrom()).Dest(ReindexTo("books-v2")).Run(ctx, client, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, len(requests()))
+}
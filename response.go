@@ -0,0 +1,134 @@
+package osquery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/opensearch-project/opensearch-go/v4"
+)
+
+// ErrorCause describes the cause of an error returned by OpenSearch, either
+// for a whole request or for a single item of a multi-item request.
+type ErrorCause struct {
+	Type      string       `json:"type"`
+	Reason    string       `json:"reason"`
+	RootCause []ErrorCause `json:"root_cause,omitempty"`
+	CausedBy  *ErrorCause  `json:"caused_by,omitempty"`
+}
+
+// Error implements the error interface.
+func (cause *ErrorCause) Error() string {
+	if cause.Reason == "" {
+		return cause.Type
+	}
+	return fmt.Sprintf("%s: %s", cause.Type, cause.Reason)
+}
+
+// ResponseError is returned when OpenSearch responds to a request with an
+// error status code.
+type ResponseError struct {
+	StatusCode int
+	Cause      *ErrorCause
+	Body       []byte
+}
+
+// Error implements the error interface.
+func (e *ResponseError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("status %d: %s", e.StatusCode, e.Cause.Error())
+	}
+	return fmt.Sprintf("status %d: %s", e.StatusCode, e.Body)
+}
+
+// newResponseError creates a ResponseError from an error response, decoding
+// its "error" object if possible.
+func newResponseError(res *opensearch.Response) *ResponseError {
+	e := &ResponseError{StatusCode: res.StatusCode}
+	if res.Body == nil {
+		return e
+	}
+
+	defer res.Body.Close()
+	e.Body, _ = io.ReadAll(res.Body)
+
+	var body struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if json.Unmarshal(e.Body, &body) != nil || len(body.Error) == 0 {
+		return e
+	}
+
+	var cause ErrorCause
+	if json.Unmarshal(body.Error, &cause) == nil {
+		e.Cause = &cause
+	} else {
+		var reason string
+		if json.Unmarshal(body.Error, &reason) == nil {
+			e.Cause = &ErrorCause{Reason: reason}
+		}
+	}
+
+	return e
+}
+
+// execute executes the provided request using the OpenSearch client's Do
+// method and decodes the response body into dest. Unlike calling Do directly,
+// error status codes are reported as a *ResponseError.
+func execute(
+	ctx context.Context,
+	client *opensearch.Client,
+	req opensearch.Request,
+	dest interface{},
+) error {
+	res, err := client.Do(ctx, req, dest)
+	if err != nil {
+		return err
+	}
+	if res.IsError() {
+		return newResponseError(res)
+	}
+	return nil
+}
+
+// BulkByScrollResponse represents the response of the APIs processing
+// documents in scrolled batches: update by query, delete by query and
+// reindex. When such a request is run as a task, only Task is set, holding
+// the ID of the task that can be used with the Tasks API.
+type BulkByScrollResponse struct {
+	Took                 int                   `json:"took"`
+	TimedOut             bool                  `json:"timed_out"`
+	Total                int                   `json:"total"`
+	Updated              int                   `json:"updated"`
+	Created              int                   `json:"created"`
+	Deleted              int                   `json:"deleted"`
+	Batches              int                   `json:"batches"`
+	VersionConflicts     int                   `json:"version_conflicts"`
+	Noops                int                   `json:"noops"`
+	Retries              BulkByScrollRetries   `json:"retries"`
+	ThrottledMillis      int                   `json:"throttled_millis"`
+	RequestsPerSecond    float64               `json:"requests_per_second"`
+	ThrottledUntilMillis int                   `json:"throttled_until_millis"`
+	Failures             []BulkByScrollFailure `json:"failures"`
+	Task                 string                `json:"task,omitempty"`
+}
+
+// BulkByScrollRetries holds the number of retries of a BulkByScrollResponse.
+type BulkByScrollRetries struct {
+	Bulk   int `json:"bulk"`
+	Search int `json:"search"`
+}
+
+// BulkByScrollFailure describes a failure of a BulkByScrollResponse. Failures
+// of bulk actions hold the ID of the document and a Cause, while failures of
+// the search hold the Shard and Node and a Reason.
+type BulkByScrollFailure struct {
+	Index  string      `json:"index"`
+	ID     string      `json:"id,omitempty"`
+	Status int         `json:"status,omitempty"`
+	Cause  *ErrorCause `json:"cause,omitempty"`
+	Shard  *int        `json:"shard,omitempty"`
+	Node   string      `json:"node,omitempty"`
+	Reason *ErrorCause `json:"reason,omitempty"`
+}
@@ -26,6 +26,15 @@ type SearchRequest struct {
 	source       Source
 	timeout      *time.Duration
 	scriptFields []*ScriptField
+	pit          *pointInTime
+
+	searchPipeline string
+}
+
+// pointInTime represents the "pit" option of a search request.
+type pointInTime struct {
+	id        string
+	keepAlive time.Duration
 }
 
 // Search creates a new SearchRequest object, to be filled via method chaining.
@@ -116,6 +125,26 @@ func (req *SearchRequest) Highlight(highlight Mappable) *SearchRequest {
 	return req
 }
 
+// PointInTime sets a point in time (PIT) to search, as created by CreatePIT.
+// If keepAlive is non-zero, it extends the life of the point in time. Since a
+// point in time is bound to the indices it was created for, no indices are
+// sent with the request, even if they were provided via Options.
+func (req *SearchRequest) PointInTime(id string, keepAlive time.Duration) *SearchRequest {
+	req.pit = &pointInTime{
+		id:        id,
+		keepAlive: keepAlive,
+	}
+	return req
+}
+
+// SearchPipeline sets the search pipeline processing the request, such as one
+// normalizing and combining the scores of a Hybrid query. It overrides the
+// pipeline set via Options.Params, and is ignored by multi-searches.
+func (req *SearchRequest) SearchPipeline(name string) *SearchRequest {
+	req.searchPipeline = name
+	return req
+}
+
 func (req *SearchRequest) ScriptFields(fields ...*ScriptField) *SearchRequest {
 	req.scriptFields = append(req.scriptFields, fields...)
 	return req
@@ -180,6 +209,15 @@ func (req *SearchRequest) Map() map[string]interface{} {
 	if len(source) > 0 {
 		m["_source"] = source
 	}
+	if req.pit != nil {
+		pit := map[string]interface{}{
+			"id": req.pit.id,
+		}
+		if req.pit.keepAlive > 0 {
+			pit["keep_alive"] = formatDuration(req.pit.keepAlive)
+		}
+		m["pit"] = pit
+	}
 
 	return m
 }
@@ -195,13 +233,50 @@ func (req *SearchRequest) Run(
 	client *opensearch.Client,
 	options *Options,
 ) (*opensearchapi.SearchResp, error) {
+	// Create a variable to hold the response
+	var searchResp opensearchapi.SearchResp
+
+	if err := req.run(ctx, client, options, &searchResp); err != nil {
+		return nil, err
+	}
+
+	// Return the parsed response
+	return &searchResp, nil
+}
+
+// run executes the search and decodes the response body into dest, which is
+// shared by Run and the typed RunInto.
+func (req *SearchRequest) run(
+	ctx context.Context,
+	client *opensearch.Client,
+	options *Options,
+	dest interface{},
+) error {
+	searchReq, err := req.searchReq(options)
+	if err != nil {
+		return err
+	}
+
+	if err := execute(ctx, client, searchReq, dest); err != nil {
+		return fmt.Errorf("search request failed: %w", err)
+	}
+
+	return nil
+}
+
+// searchReq creates the opensearchapi request for the search, applying
+// additional options if provided.
+func (req *SearchRequest) searchReq(options *Options) (opensearchapi.SearchReq, error) {
+	if err := ValidateBucketsPaths(req.aggs...); err != nil {
+		return opensearchapi.SearchReq{}, err
+	}
+
 	// Serialize the request body to JSON
 	body, err := json.Marshal(req.Map())
 	if err != nil {
-		return nil, fmt.Errorf("failed to serialize request body: %w", err)
+		return opensearchapi.SearchReq{}, fmt.Errorf("failed to serialize request body: %w", err)
 	}
 
-	// Create a Search request, setting size to 0 to avoid fetching documents
 	searchReq := opensearchapi.SearchReq{
 		Body: bytes.NewReader(body),
 	}
@@ -209,21 +284,34 @@ func (req *SearchRequest) Run(
 	// Apply additional options if provided
 	err = ApplyOptions(&searchReq, options)
 	if err != nil {
-		return nil, err
+		return opensearchapi.SearchReq{}, err
 	}
 
-	// fmt.Printf("%+v", searchReq)
-
-	// Create a variable to hold the response
-	var searchResp opensearchapi.SearchResp
+	// A point in time already determines the indices to search
+	if req.pit != nil {
+		searchReq.Indices = nil
+	}
 
-	// Execute the search request using the OpenSearch client's Do method
-	if _, err := client.Do(ctx, searchReq, &searchResp); err != nil {
-		return nil, fmt.Errorf("search request failed: %w", err)
+	if req.searchPipeline != "" {
+		searchReq.Params.SearchPipeline = req.searchPipeline
 	}
 
-	// Return the parsed response
-	return &searchResp, nil
+	return searchReq, nil
+}
+
+// clone returns a copy of the request that can be modified without affecting
+// the original.
+func (req *SearchRequest) clone() *SearchRequest {
+	c := *req
+	c.aggs = append([]Aggregation(nil), req.aggs...)
+	c.sort = append([]SortOption(nil), req.sort...)
+	c.searchAfter = append([]interface{}(nil), req.searchAfter...)
+	c.scriptFields = append([]*ScriptField(nil), req.scriptFields...)
+	if req.pit != nil {
+		pit := *req.pit
+		c.pit = &pit
+	}
+	return &c
 }
 
 // Query is a shortcut for creating a SearchRequest with only a query. It is
@@ -0,0 +1,287 @@
+package osquery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// defaultPageSize is the number of hits OpenSearch returns when a search
+// request does not set a size.
+const defaultPageSize = 10
+
+// SearchIterator pages through all hits matching a SearchRequest, decoding
+// each of them into a SearchHit[T]. By default, pages are requested using
+// "search_after" with the request's sort options, to which a tie-breaker is
+// appended so that hits sharing the same sort values are neither skipped nor
+// returned twice. Alternatively, the scroll API can be used by calling
+// Scroll. If the request searches a point in time (see
+// SearchRequest.PointInTime), the point in time ID is updated from each page,
+// so that all pages see the same consistent view of the data.
+//
+// Iterators are used like this:
+//
+//	it := osquery.Iterate[Book](osquery.Search().Query(q).Size(500), client, options)
+//	defer it.Close(ctx)
+//	for it.Next(ctx) {
+//		book := it.Hit().Source
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+type SearchIterator[T any] struct {
+	req        *SearchRequest
+	client     *opensearch.Client
+	options    *Options
+	scroll     time.Duration
+	tieBreaker SortOption
+
+	page     []SearchHit[T]
+	pos      int
+	scrollID string
+	started  bool
+	done     bool
+	err      error
+}
+
+// Iterate creates a new SearchIterator over all hits of the provided search
+// request. The request itself is not modified by the iterator.
+func Iterate[T any](
+	req *SearchRequest,
+	client *opensearch.Client,
+	options *Options,
+) *SearchIterator[T] {
+	return &SearchIterator[T]{
+		req:        req.clone(),
+		client:     client,
+		options:    options,
+		tieBreaker: FieldSort("_id").Order(OrderAsc),
+	}
+}
+
+// Scroll makes the iterator use the scroll API instead of "search_after",
+// keeping the search context alive for the provided duration between pages.
+func (it *SearchIterator[T]) Scroll(keepAlive time.Duration) *SearchIterator[T] {
+	it.scroll = keepAlive
+	return it
+}
+
+// TieBreaker sets the sort option appended to the request's sort options when
+// paging with "search_after". It must sort on a field that is unique per
+// document. The default is to sort on "_id". Passing nil disables the
+// tie-breaker, in which case the request's sort options must already be
+// unique; the iteration fails if hits are returned without sort values.
+func (it *SearchIterator[T]) TieBreaker(opt SortOption) *SearchIterator[T] {
+	it.tieBreaker = opt
+	return it
+}
+
+// Next advances the iterator to the next hit, fetching a new page when needed.
+// It returns false once all hits were consumed, or if an error occurred, in
+// which case Err returns it. If the context is canceled, any open scroll
+// context is cleared.
+func (it *SearchIterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	if it.pos+1 < len(it.page) {
+		it.pos++
+		return true
+	}
+
+	if it.done {
+		return false
+	}
+
+	if err := ctx.Err(); err != nil {
+		it.fail(err)
+		return false
+	}
+
+	if err := it.fetch(ctx); err != nil {
+		it.fail(err)
+		return false
+	}
+
+	if len(it.page) == 0 {
+		it.done = true
+		it.err = it.Close(ctx)
+		return false
+	}
+
+	return true
+}
+
+// Hit returns the current hit. It must only be called after Next returned
+// true.
+func (it *SearchIterator[T]) Hit() *SearchHit[T] {
+	if it.pos >= len(it.page) {
+		return nil
+	}
+	return &it.page[it.pos]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *SearchIterator[T]) Err() error {
+	return it.err
+}
+
+// Close releases the resources held by the iterator, clearing the scroll
+// context if one is open. It is safe to call Close multiple times.
+func (it *SearchIterator[T]) Close(ctx context.Context) error {
+	it.done = true
+	if it.scrollID == "" {
+		return nil
+	}
+
+	scrollID := it.scrollID
+	it.scrollID = ""
+
+	var res opensearchapi.ScrollDeleteResp
+	err := execute(ctx, it.client, opensearchapi.ScrollDeleteReq{
+		ScrollIDs: []string{scrollID},
+	}, &res)
+	if err != nil {
+		return fmt.Errorf("failed clearing scroll: %w", err)
+	}
+
+	return nil
+}
+
+// fail stops the iteration with the provided error, clearing the scroll
+// context in the background if the iteration was canceled.
+func (it *SearchIterator[T]) fail(err error) {
+	it.err = err
+	it.page = nil
+	it.pos = 0
+
+	ctx := context.Background()
+	if closeErr := it.Close(ctx); closeErr != nil {
+		it.err = errors.Join(err, closeErr)
+	}
+}
+
+func (it *SearchIterator[T]) fetch(ctx context.Context) error {
+	var res SearchResult[T]
+	var err error
+
+	switch {
+	case it.scroll > 0 && it.req.pit != nil:
+		return errors.New("scroll cannot be used with a point in time")
+	case it.scroll > 0 && it.started:
+		err = it.nextScroll(ctx, &res)
+	case it.scroll > 0:
+		err = it.startScroll(ctx, &res)
+	default:
+		err = it.searchAfter(ctx, &res)
+	}
+	if err != nil {
+		return err
+	}
+
+	it.started = true
+	it.page = res.Hits.Hits
+	it.pos = 0
+
+	if res.ScrollID != "" {
+		it.scrollID = res.ScrollID
+	}
+	if res.PitID != "" && it.req.pit != nil {
+		it.req.pit.id = res.PitID
+	}
+
+	if len(it.page) < it.pageSize() {
+		// this page is the last one, no need for another round-trip
+		it.done = true
+		if it.scroll > 0 {
+			return it.Close(ctx)
+		}
+	}
+
+	return nil
+}
+
+func (it *SearchIterator[T]) pageSize() int {
+	if it.req.size != nil {
+		return int(*it.req.size)
+	}
+	return defaultPageSize
+}
+
+func (it *SearchIterator[T]) searchAfter(ctx context.Context, res *SearchResult[T]) error {
+	if !it.started {
+		// search_after cannot be combined with an offset
+		it.req.from = nil
+		if it.tieBreaker != nil && !hasSort(it.req.sort, it.tieBreaker) {
+			it.req.sort = append(it.req.sort, it.tieBreaker)
+		}
+	} else if len(it.page) > 0 {
+		last := it.page[len(it.page)-1]
+		if len(last.Sort) == 0 {
+			// without sort values, the same page would be fetched forever
+			return errors.New("search_after requires sort values, set a sort or a tie-breaker")
+		}
+		it.req.searchAfter = last.Sort
+	}
+
+	searchReq, err := it.req.searchReq(it.options)
+	if err != nil {
+		return err
+	}
+
+	if err := execute(ctx, it.client, searchReq, res); err != nil {
+		return fmt.Errorf("search request failed: %w", err)
+	}
+
+	return nil
+}
+
+func (it *SearchIterator[T]) startScroll(ctx context.Context, res *SearchResult[T]) error {
+	// scroll cannot be combined with an offset
+	it.req.from = nil
+
+	searchReq, err := it.req.searchReq(it.options)
+	if err != nil {
+		return err
+	}
+	searchReq.Params.Scroll = it.scroll
+
+	if err := execute(ctx, it.client, searchReq, res); err != nil {
+		return fmt.Errorf("search request failed: %w", err)
+	}
+
+	return nil
+}
+
+func (it *SearchIterator[T]) nextScroll(ctx context.Context, res *SearchResult[T]) error {
+	scrollReq := opensearchapi.ScrollGetReq{
+		ScrollID: it.scrollID,
+		Params: opensearchapi.ScrollGetParams{
+			Scroll: it.scroll,
+		},
+	}
+
+	if err := execute(ctx, it.client, scrollReq, res); err != nil {
+		return fmt.Errorf("scroll request failed: %w", err)
+	}
+
+	return nil
+}
+
+// hasSort returns whether opts already include a sort on the same key as opt.
+func hasSort(opts []SortOption, opt SortOption) bool {
+	for key := range opt.Map() {
+		for _, o := range opts {
+			if _, ok := o.Map()[key]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+package osquery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jgroeneveld/trial/assert"
+	"github.com/opensearch-project/opensearch-go/v4"
+)
+
+// recordedRequest is a request received by a test server.
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+}
+
+// newTestClient starts an HTTP server that records every request and answers
+// with the responses returned by handle, and returns a client connected to it.
+func newTestClient(
+	t *testing.T,
+	handle func(n int, req recordedRequest) (int, string),
+) (*opensearch.Client, func() []recordedRequest) {
+	var (
+		mu       sync.Mutex
+		requests []recordedRequest
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		req := recordedRequest{r.Method, r.URL.Path, r.URL.RawQuery, string(body)}
+
+		mu.Lock()
+		requests = append(requests, req)
+		n := len(requests) - 1
+		mu.Unlock()
+
+		status, resp := handle(n, req)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(resp))
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := opensearch.NewClient(opensearch.Config{
+		Addresses:    []string{srv.URL},
+		DisableRetry: true,
+	})
+	if err != nil {
+		t.Fatalf("failed creating client: %s", err)
+	}
+
+	return client, func() []recordedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]recordedRequest(nil), requests...)
+	}
+}
+
+// hitsPage returns a search response holding hits with the provided IDs.
+func hitsPage(scrollID string, ids ...int) string {
+	hits := make([]string, 0, len(ids))
+	for _, id := range ids {
+		hits = append(hits, fmt.Sprintf(
+			`{"_index":"test","_id":"%d","_source":{"n":%d},"sort":[5,"%d"]}`,
+			id, id, id,
+		))
+	}
+	scroll := ""
+	if scrollID != "" {
+		scroll = fmt.Sprintf(`"_scroll_id":"%s",`, scrollID)
+	}
+	return fmt.Sprintf(
+		`{%s"hits":{"total":{"value":5,"relation":"eq"},"hits":[%s]}}`,
+		scroll, strings.Join(hits, ","),
+	)
+}
+
+type iteratorDoc struct {
+	N int `json:"n"`
+}
+
+func TestSearchIteratorSearchAfter(t *testing.T) {
+	client, requests := newTestClient(t, func(n int, _ recordedRequest) (int, string) {
+		switch n {
+		case 0:
+			return 200, hitsPage("", 1, 2)
+		case 1:
+			return 200, hitsPage("", 3, 4)
+		default:
+			return 200, hitsPage("", 5)
+		}
+	})
+
+	req := Search().Query(MatchAll()).Size(2).From(4).Sort(FieldSort("rank").Order(OrderDesc))
+	it := Iterate[iteratorDoc](req, client, &Options{Indices: []string{"test"}})
+
+	var got []int
+	for it.Next(context.Background()) {
+		got = append(got, it.Hit().Source.N)
+	}
+	assert.Nil(t, it.Err())
+	assert.DeepEqual(t, []int{1, 2, 3, 4, 5}, got)
+
+	reqs := requests()
+	assert.Equal(t, 3, len(reqs))
+	assert.Equal(t, "/test/_search", reqs[0].Path)
+
+	var first, second map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(reqs[0].Body), &first))
+	assert.Nil(t, json.Unmarshal([]byte(reqs[1].Body), &second))
+
+	_, hasFrom := first["from"]
+	assert.False(t, hasFrom)
+	assert.DeepEqual(t, []interface{}{
+		map[string]interface{}{"rank": map[string]interface{}{"order": "desc"}},
+		map[string]interface{}{"_id": map[string]interface{}{"order": "asc"}},
+	}, first["sort"])
+	assert.DeepEqual(t, []interface{}{5.0, "2"}, second["search_after"])
+
+	// the original request must not have been modified
+	assert.Equal(t, 1, len(req.sort))
+	assert.Equal(t, uint64(4), *req.from)
+}
+
+func TestSearchIteratorScroll(t *testing.T) {
+	client, requests := newTestClient(t, func(n int, _ recordedRequest) (int, string) {
+		switch n {
+		case 0:
+			return 200, hitsPage("scroll-1", 1, 2)
+		case 1:
+			return 200, hitsPage("scroll-2", 3)
+		default:
+			return 200, `{"succeeded":true,"num_freed":1}`
+		}
+	})
+
+	it := Iterate[iteratorDoc](Search().Size(2).From(4), client, nil).Scroll(time.Minute)
+
+	var got []int
+	for it.Next(context.Background()) {
+		got = append(got, it.Hit().Source.N)
+	}
+	assert.Nil(t, it.Err())
+	assert.DeepEqual(t, []int{1, 2, 3}, got)
+
+	reqs := requests()
+	assert.Equal(t, 3, len(reqs))
+	assert.Equal(t, "scroll=60000ms", reqs[0].Query)
+	assert.Equal(t, `{"size":2}`, reqs[0].Body)
+	assert.Equal(t, "/_search/scroll", reqs[1].Path)
+	assert.Equal(t, `{"scroll_id":"scroll-1"}`, reqs[1].Body)
+	assert.Equal(t, "DELETE", reqs[2].Method)
+	assert.Equal(t, "/_search/scroll/scroll-2", reqs[2].Path)
+}
+
+func TestSearchIteratorCancel(t *testing.T) {
+	client, requests := newTestClient(t, func(n int, _ recordedRequest) (int, string) {
+		if n == 0 {
+			return 200, hitsPage("scroll-1", 1, 2)
+		}
+		return 200, `{"succeeded":true,"num_freed":1}`
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := Iterate[iteratorDoc](Search().Size(2), client, nil).Scroll(time.Minute)
+
+	assert.True(t, it.Next(ctx))
+	assert.True(t, it.Next(ctx))
+	cancel()
+	assert.False(t, it.Next(ctx))
+	assert.Equal(t, context.Canceled, it.Err())
+
+	reqs := requests()
+	assert.Equal(t, 2, len(reqs))
+	assert.Equal(t, "DELETE", reqs[1].Method)
+}
+
+func TestSearchIteratorError(t *testing.T) {
+	client, _ := newTestClient(t, func(int, recordedRequest) (int, string) {
+		return 400, `{"error":{"type":"parsing_exception","reason":"bad query"},"status":400}`
+	})
+
+	it := Iterate[iteratorDoc](Search(), client, nil)
+	assert.False(t, it.Next(context.Background()))
+
+	var resErr *ResponseError
+	assert.True(t, errors.As(it.Err(), &resErr))
+	assert.Equal(t, 400, resErr.StatusCode)
+	assert.Equal(t, "parsing_exception", resErr.Cause.Type)
+}
+
+func TestSearchIteratorMissingSortValues(t *testing.T) {
+	client, requests := newTestClient(t, func(int, recordedRequest) (int, string) {
+		return 200, `{"hits":{"hits":[
+			{"_index":"test","_id":"1","_source":{"n":1}},
+			{"_index":"test","_id":"2","_source":{"n":2}}
+		]}}`
+	})
+
+	it := Iterate[iteratorDoc](Search().Size(2), client, nil).TieBreaker(nil)
+	assert.True(t, it.Next(context.Background()))
+	assert.True(t, it.Next(context.Background()))
+	assert.False(t, it.Next(context.Background()))
+	assert.NotNil(t, it.Err())
+	assert.Equal(t, 1, len(requests()))
+}
@@ -0,0 +1,160 @@
+package osquery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// SearchResult represents the response of a search request, with the
+// "_source" of every hit decoded into a value of type T.
+type SearchResult[T any] struct {
+	Took         int                          `json:"took"`
+	TimedOut     bool                         `json:"timed_out"`
+	Shards       opensearchapi.ResponseShards `json:"_shards"`
+	Hits         SearchHits[T]                `json:"hits"`
+	Aggregations AggregationResults           `json:"aggregations,omitempty"`
+	ScrollID     string                       `json:"_scroll_id,omitempty"`
+	PitID        string                       `json:"pit_id,omitempty"`
+}
+
+// SearchHits represents the "hits" section of a search response.
+type SearchHits[T any] struct {
+	Total    HitsTotal      `json:"total"`
+	MaxScore *float64       `json:"max_score"`
+	Hits     []SearchHit[T] `json:"hits"`
+}
+
+// HitsTotal represents the total number of hits matching a search request.
+// Relation is "eq" if Value is accurate, or "gte" if it is a lower bound.
+type HitsTotal struct {
+	Value    int64  `json:"value"`
+	Relation string `json:"relation"`
+}
+
+// SearchHit represents a single hit of a search response, with its "_source"
+// decoded into a value of type T.
+type SearchHit[T any] struct {
+	Index       string                     `json:"_index"`
+	ID          string                     `json:"_id"`
+	Routing     string                     `json:"_routing,omitempty"`
+	Score       *float64                   `json:"_score"`
+	Source      T                          `json:"_source"`
+	Fields      map[string]json.RawMessage `json:"fields,omitempty"`
+	Sort        SortValues                 `json:"sort,omitempty"`
+	Highlight   map[string][]string        `json:"highlight,omitempty"`
+	InnerHits   map[string]InnerHitsResult `json:"inner_hits,omitempty"`
+	Nested      *NestedIdentity            `json:"_nested,omitempty"`
+	Explanation json.RawMessage            `json:"_explanation,omitempty"`
+	Version     *int64                     `json:"_version,omitempty"`
+	SeqNo       *int64                     `json:"_seq_no,omitempty"`
+	PrimaryTerm *int64                     `json:"_primary_term,omitempty"`
+}
+
+// InnerHitsResult represents the inner hits returned for a single hit. Since
+// inner hits usually hold documents of a different shape than the top level
+// hits, their "_source" is kept raw.
+type InnerHitsResult struct {
+	Hits SearchHits[json.RawMessage] `json:"hits"`
+}
+
+// NestedIdentity identifies the nested object an inner hit originates from.
+type NestedIdentity struct {
+	Field  string          `json:"field"`
+	Offset int             `json:"offset"`
+	Nested *NestedIdentity `json:"_nested,omitempty"`
+}
+
+// SortValues holds the sort values of a hit. Numbers are kept as json.Number
+// so that they can be passed back to SearchAfter without losing precision.
+type SortValues []interface{}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *SortValues) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var values []interface{}
+	if err := dec.Decode(&values); err != nil {
+		return err
+	}
+	*s = values
+	return nil
+}
+
+// Sources returns the decoded "_source" of every hit, in order.
+func (res *SearchResult[T]) Sources() []T {
+	sources := make([]T, 0, len(res.Hits.Hits))
+	for _, hit := range res.Hits.Hits {
+		sources = append(sources, hit.Source)
+	}
+	return sources
+}
+
+// DecodeSearchHits decodes the "_source" of raw hits, such as those of a
+// MultiSearchResponse, an InnerHitsResult or a TopHitsResult, into values of
+// type T.
+func DecodeSearchHits[T any](raw SearchHits[json.RawMessage]) (SearchHits[T], error) {
+	hits := SearchHits[T]{
+		Total:    raw.Total,
+		MaxScore: raw.MaxScore,
+		Hits:     make([]SearchHit[T], 0, len(raw.Hits)),
+	}
+
+	for _, rawHit := range raw.Hits {
+		hit := SearchHit[T]{
+			Index:       rawHit.Index,
+			ID:          rawHit.ID,
+			Routing:     rawHit.Routing,
+			Score:       rawHit.Score,
+			Fields:      rawHit.Fields,
+			Sort:        rawHit.Sort,
+			Highlight:   rawHit.Highlight,
+			InnerHits:   rawHit.InnerHits,
+			Nested:      rawHit.Nested,
+			Explanation: rawHit.Explanation,
+			Version:     rawHit.Version,
+			SeqNo:       rawHit.SeqNo,
+			PrimaryTerm: rawHit.PrimaryTerm,
+		}
+		if len(rawHit.Source) > 0 {
+			if err := json.Unmarshal(rawHit.Source, &hit.Source); err != nil {
+				return hits, fmt.Errorf("failed to decode hit %q: %w", rawHit.ID, err)
+			}
+		}
+		hits.Hits = append(hits.Hits, hit)
+	}
+
+	return hits, nil
+}
+
+// DecodeInnerHits decodes the inner hits of the provided hit with the
+// provided name into values of type T. A hit without inner hits of that name
+// yields empty hits.
+func DecodeInnerHits[T, S any](hit *SearchHit[S], name string) (SearchHits[T], error) {
+	inner, ok := hit.InnerHits[name]
+	if !ok {
+		return SearchHits[T]{}, nil
+	}
+	return DecodeSearchHits[T](inner.Hits)
+}
+
+// RunInto executes the search request just like Run does, but decodes the
+// response into a SearchResult whose hits hold values of type T.
+func RunInto[T any](
+	ctx context.Context,
+	req *SearchRequest,
+	client *opensearch.Client,
+	options *Options,
+) (*SearchResult[T], error) {
+	var res SearchResult[T]
+	if err := req.run(ctx, client, options, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,128 @@
+package osquery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/jgroeneveld/trial/assert"
+)
+
+func TestSearchResultDecoding(t *testing.T) {
+	type doc struct {
+		Title string `json:"title"`
+	}
+
+	body := `{
+		"took": 5,
+		"timed_out": false,
+		"_shards": {"total": 1, "successful": 1, "skipped": 0, "failed": 0},
+		"hits": {
+			"total": {"value": 2, "relation": "eq"},
+			"max_score": null,
+			"hits": [
+				{
+					"_index": "books",
+					"_id": "1",
+					"_score": null,
+					"_source": {"title": "Go and Stuff"},
+					"sort": [1700000000000123456, "1"],
+					"highlight": {"title": ["<em>Go</em> and Stuff"]},
+					"inner_hits": {
+						"comments": {
+							"hits": {
+								"total": {"value": 1, "relation": "eq"},
+								"max_score": 1.5,
+								"hits": [
+									{
+										"_index": "books",
+										"_id": "1",
+										"_nested": {"field": "comments", "offset": 0},
+										"_score": 1.5,
+										"_source": {"author": "kimchy"}
+									}
+								]
+							}
+						}
+					}
+				},
+				{
+					"_index": "books",
+					"_id": "2",
+					"_score": null,
+					"_source": {"title": "More Stuff"},
+					"sort": [1700000000000123457, "2"]
+				}
+			]
+		}
+	}`
+
+	var res SearchResult[doc]
+	err := json.Unmarshal([]byte(body), &res)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 5, res.Took)
+	assert.Equal(t, int64(2), res.Hits.Total.Value)
+	assert.DeepEqual(t, []doc{{"Go and Stuff"}, {"More Stuff"}}, res.Sources())
+
+	hit := res.Hits.Hits[0]
+	assert.Equal(t, "1", hit.ID)
+	assert.DeepEqual(t, SortValues{json.Number("1700000000000123456"), "1"}, hit.Sort)
+	assert.DeepEqual(t, []string{"<em>Go</em> and Stuff"}, hit.Highlight["title"])
+
+	inner := hit.InnerHits["comments"].Hits.Hits
+	assert.Equal(t, 1, len(inner))
+	assert.Equal(t, "comments", inner[0].Nested.Field)
+	assert.Equal(t, `{"author": "kimchy"}`, string(inner[0].Source))
+
+	// sort values must round-trip into search_after without losing precision
+	b, err := json.Marshal(Search().SearchAfter(hit.Sort...).Map())
+	assert.Nil(t, err)
+	assert.Equal(t, `{"search_after":[1700000000000123456,"1"]}`, string(b))
+}
+
+func TestDecodeInnerHits(t *testing.T) {
+	type comment struct {
+		Author string `json:"author"`
+	}
+
+	var hit SearchHit[json.RawMessage]
+	err := json.Unmarshal([]byte(`{
+		"_id": "1",
+		"_source": {},
+		"inner_hits": {
+			"comments": {"hits": {
+				"total": {"value": 1, "relation": "eq"},
+				"hits": [{"_id": "1", "_nested": {"field": "comments", "offset": 2}, "_source": {"author": "kimchy"}}]
+			}}
+		}
+	}`), &hit)
+	assert.Nil(t, err)
+
+	comments, err := DecodeInnerHits[comment](&hit, "comments")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), comments.Total.Value)
+	assert.Equal(t, "kimchy", comments.Hits[0].Source.Author)
+	assert.Equal(t, 2, comments.Hits[0].Nested.Offset)
+
+	missing, err := DecodeInnerHits[comment](&hit, "missing")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(missing.Hits))
+}
+
+func TestRunIntoError(t *testing.T) {
+	client, _ := newTestClient(t, func(int, recordedRequest) (int, string) {
+		return 404, `{"error":{"type":"index_not_found_exception","reason":"no such index [missing]"},"status":404}`
+	})
+
+	res, err := RunInto[iteratorDoc](context.Background(), Search().Query(MatchAll()), client, &Options{
+		Indices: []string{"missing"},
+	})
+	assert.True(t, res == nil)
+
+	var resErr *ResponseError
+	assert.True(t, errors.As(err, &resErr))
+	assert.Equal(t, 404, resErr.StatusCode)
+	assert.Equal(t, "index_not_found_exception", resErr.Cause.Type)
+}
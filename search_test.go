@@ -7,6 +7,9 @@ package osquery
 import (
 	"testing"
 	"time"
+
+	"github.com/jgroeneveld/trial/assert"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
 )
 
 func TestSearchMaps(t *testing.T) {
@@ -160,5 +163,32 @@ func TestSearchMaps(t *testing.T) {
 				},
 			},
 		},
+		{
+			"a search on a point in time",
+			Search().Query(MatchAll()).PointInTime("pit-id", 2*time.Minute),
+			map[string]interface{}{
+				"query": map[string]interface{}{
+					"match_all": map[string]interface{}{},
+				},
+				"pit": map[string]interface{}{
+					"id":         "pit-id",
+					"keep_alive": "120000ms",
+				},
+			},
+		},
 	})
 }
+
+func TestSearchPipeline(t *testing.T) {
+	options := &Options{
+		Params: &opensearchapi.SearchParams{SearchPipeline: "default"},
+	}
+
+	searchReq, err := Search().Query(MatchAll()).searchReq(options)
+	assert.Nil(t, err)
+	assert.Equal(t, "default", searchReq.Params.SearchPipeline)
+
+	searchReq, err = Search().Query(MatchAll()).SearchPipeline("nlp-search").searchReq(options)
+	assert.Nil(t, err)
+	assert.Equal(t, "nlp-search", searchReq.Params.SearchPipeline)
+}
@@ -158,3 +158,91 @@ func (f *FieldSortOption) Map() map[string]any {
 		f.field: sortOptions,
 	}
 }
+
+// GeoDistanceSortOption represents a sort option of type "_geo_distance",
+// sorting documents by the distance between the points of a field and one or
+// more origin points.
+type GeoDistanceSortOption struct {
+	field          string
+	points         []GeoPoint
+	order          Order
+	unit           string
+	mode           Mode
+	distanceType   GeoDistanceType
+	ignoreUnmapped *bool
+}
+
+// GeoDistanceSort creates a new sort option of type "_geo_distance" on the
+// provided field. With several points, the distance to a document is the
+// minimum (or per Mode) distance to any of them.
+func GeoDistanceSort(field string, points ...GeoPoint) *GeoDistanceSortOption {
+	return &GeoDistanceSortOption{
+		field:  field,
+		points: points,
+	}
+}
+
+func (g *GeoDistanceSortOption) Order(order Order) *GeoDistanceSortOption {
+	g.order = order
+	return g
+}
+
+func (g *GeoDistanceSortOption) GetOrder() Order {
+	return g.order
+}
+
+// Unit sets the unit of the distances returned as sort values (e.g. "km").
+// The default is meters.
+func (g *GeoDistanceSortOption) Unit(unit string) *GeoDistanceSortOption {
+	g.unit = unit
+	return g
+}
+
+// Mode sets which distance is used when a document has several points.
+func (g *GeoDistanceSortOption) Mode(mode Mode) *GeoDistanceSortOption {
+	g.mode = mode
+	return g
+}
+
+// DistanceType sets how distances are computed.
+func (g *GeoDistanceSortOption) DistanceType(t GeoDistanceType) *GeoDistanceSortOption {
+	g.distanceType = t
+	return g
+}
+
+// IgnoreUnmapped sets whether an unmapped field is treated as having no
+// value, rather than failing the search.
+func (g *GeoDistanceSortOption) IgnoreUnmapped(b bool) *GeoDistanceSortOption {
+	g.ignoreUnmapped = &b
+	return g
+}
+
+func (g *GeoDistanceSortOption) Map() map[string]any {
+	sortOptions := map[string]any{
+		g.field: geoPointsMap(g.points),
+	}
+
+	if g.order != "" {
+		sortOptions["order"] = g.order
+	}
+
+	if g.unit != "" {
+		sortOptions["unit"] = g.unit
+	}
+
+	if g.mode != "" {
+		sortOptions["mode"] = g.mode
+	}
+
+	if g.distanceType != "" {
+		sortOptions["distance_type"] = g.distanceType
+	}
+
+	if g.ignoreUnmapped != nil {
+		sortOptions["ignore_unmapped"] = *g.ignoreUnmapped
+	}
+
+	return map[string]any{
+		"_geo_distance": sortOptions,
+	}
+}
@@ -255,3 +255,33 @@ func TestScriptSortExtensions(t *testing.T) {
 		},
 	})
 }
+
+func TestGeoDistanceSort(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"geo distance sort",
+			Search().Sort(
+				GeoDistanceSort("location", LatLon(40.7, -73.9)).
+					Order(OrderAsc).
+					Unit("km").
+					Mode(SortModeMin).
+					DistanceType(GeoDistanceArc).
+					IgnoreUnmapped(true),
+			),
+			map[string]any{
+				"sort": []map[string]any{
+					{
+						"_geo_distance": map[string]any{
+							"location":        []map[string]any{{"lat": 40.7, "lon": -73.9}},
+							"order":           "asc",
+							"unit":            "km",
+							"mode":            "min",
+							"distance_type":   "arc",
+							"ignore_unmapped": true,
+						},
+					},
+				},
+			},
+		},
+	})
+}
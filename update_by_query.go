@@ -0,0 +1,177 @@
+package osquery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// Conflicts is an enumeration of the ways version conflicts are handled by
+// the update by query, delete by query and reindex APIs.
+type Conflicts string
+
+const (
+	// ConflictsAbort aborts the request on the first version conflict. This
+	// is the default.
+	ConflictsAbort Conflicts = "abort"
+
+	// ConflictsProceed counts version conflicts and continues processing.
+	ConflictsProceed Conflicts = "proceed"
+)
+
+// slicesAuto is the value of the "slices" parameter letting OpenSearch
+// choose the number of slices.
+const slicesAuto = "auto"
+
+// UpdateByQueryRequest represents a request to OpenSearch's Update By Query
+// API, as described in:
+// https://opensearch.org/docs/latest/api-reference/document-apis/update-by-query/
+type UpdateByQueryRequest struct {
+	index             []string
+	query             Mappable
+	script            *ScriptField
+	conflicts         Conflicts
+	maxDocs           *uint64
+	slices            interface{}
+	refresh           *bool
+	waitForCompletion *bool
+}
+
+// UpdateByQuery creates a new UpdateByQueryRequest object, to be filled via
+// method chaining.
+func UpdateByQuery() *UpdateByQueryRequest {
+	return &UpdateByQueryRequest{}
+}
+
+// Index sets the index names for the request. They take precedence over the
+// indices provided via Options.
+func (req *UpdateByQueryRequest) Index(index ...string) *UpdateByQueryRequest {
+	req.index = index
+	return req
+}
+
+// Query sets the query selecting the documents to update. Without a query,
+// all documents are updated.
+func (req *UpdateByQueryRequest) Query(q Mappable) *UpdateByQueryRequest {
+	req.query = q
+	return req
+}
+
+// Script sets the script used to update every document. Without a script,
+// documents are reindexed as-is, e.g. to pick up mapping changes.
+func (req *UpdateByQueryRequest) Script(script *ScriptField) *UpdateByQueryRequest {
+	req.script = script
+	return req
+}
+
+// Conflicts sets how version conflicts are handled.
+func (req *UpdateByQueryRequest) Conflicts(conflicts Conflicts) *UpdateByQueryRequest {
+	req.conflicts = conflicts
+	return req
+}
+
+// MaxDocs sets the maximum number of documents to update.
+func (req *UpdateByQueryRequest) MaxDocs(maxDocs uint64) *UpdateByQueryRequest {
+	req.maxDocs = &maxDocs
+	return req
+}
+
+// Slices sets the number of slices the request is divided into, to be
+// processed in parallel.
+func (req *UpdateByQueryRequest) Slices(slices uint) *UpdateByQueryRequest {
+	req.slices = int(slices)
+	return req
+}
+
+// SlicesAuto lets OpenSearch choose the number of slices the request is
+// divided into.
+func (req *UpdateByQueryRequest) SlicesAuto() *UpdateByQueryRequest {
+	req.slices = slicesAuto
+	return req
+}
+
+// Refresh sets whether the affected shards are refreshed once the request
+// completes.
+func (req *UpdateByQueryRequest) Refresh(b bool) *UpdateByQueryRequest {
+	req.refresh = &b
+	return req
+}
+
+// WaitForCompletion sets whether the request blocks until the operation
+// completes. When false, OpenSearch runs the operation as a task and the
+// response only holds the task's ID.
+func (req *UpdateByQueryRequest) WaitForCompletion(b bool) *UpdateByQueryRequest {
+	req.waitForCompletion = &b
+	return req
+}
+
+// Map returns a map representation of the request's body, thus implementing
+// the Mappable interface.
+func (req *UpdateByQueryRequest) Map() map[string]interface{} {
+	m := make(map[string]interface{})
+	if req.query != nil {
+		m["query"] = req.query.Map()
+	}
+	if req.script != nil {
+		m["script"] = req.script.Map()["script"]
+	}
+	if req.conflicts != "" {
+		m["conflicts"] = req.conflicts
+	}
+	if req.maxDocs != nil {
+		m["max_docs"] = *req.maxDocs
+	}
+
+	return m
+}
+
+// Run executes the request using the provided OpenSearch client. The options'
+// Params, if provided, must be a *opensearchapi.UpdateByQueryParams; values
+// set on the request itself take precedence over them.
+func (req *UpdateByQueryRequest) Run(
+	ctx context.Context,
+	client *opensearch.Client,
+	options *Options,
+) (*BulkByScrollResponse, error) {
+	body, err := json.Marshal(req.Map())
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize request body: %w", err)
+	}
+
+	updateReq := opensearchapi.UpdateByQueryReq{
+		Body: bytes.NewReader(body),
+	}
+
+	// Apply additional options if provided
+	err = ApplyOptions(&updateReq, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.index) > 0 {
+		updateReq.Indices = req.index
+	}
+	if len(updateReq.Indices) == 0 {
+		return nil, fmt.Errorf("update by query request requires at least one index")
+	}
+	if req.slices != nil {
+		updateReq.Params.Slices = req.slices
+	}
+	if req.refresh != nil {
+		updateReq.Params.Refresh = req.refresh
+	}
+	if req.waitForCompletion != nil {
+		updateReq.Params.WaitForCompletion = req.waitForCompletion
+	}
+
+	var updateResp BulkByScrollResponse
+	if err := execute(ctx, client, updateReq, &updateResp); err != nil {
+		return nil, fmt.Errorf("update by query request failed: %w", err)
+	}
+
+	return &updateResp, nil
+}
@@ -0,0 +1,86 @@
+package osquery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jgroeneveld/trial/assert"
+)
+
+func TestUpdateByQueryMaps(t *testing.T) {
+	runMapTests(t, []mapTest{
+		{
+			"empty update by query",
+			UpdateByQuery(),
+			map[string]interface{}{},
+		},
+		{
+			"update by query with script",
+			UpdateByQuery().
+				Query(Term("status", "draft")).
+				Script(Script("").Source("ctx._source.views = 0").Lang("painless")).
+				Conflicts(ConflictsProceed).
+				MaxDocs(100),
+			map[string]interface{}{
+				"query": map[string]interface{}{
+					"term": map[string]interface{}{
+						"status": map[string]interface{}{
+							"value": "draft",
+						},
+					},
+				},
+				"script": map[string]interface{}{
+					"source": "ctx._source.views = 0",
+					"lang":   "painless",
+				},
+				"conflicts": "proceed",
+				"max_docs":  100,
+			},
+		},
+	})
+}
+
+func TestUpdateByQueryRun(t *testing.T) {
+	client, requests := newTestClient(t, func(n int, _ recordedRequest) (int, string) {
+		if n == 0 {
+			return 200, `{"took":147,"timed_out":false,"total":5,"updated":4,"deleted":0,
+				"batches":1,"version_conflicts":1,"noops":0,
+				"retries":{"bulk":0,"search":0},"throttled_millis":0,
+				"requests_per_second":-1.0,"throttled_until_millis":0,
+				"failures":[{"index":"books","id":"3","status":409,"cause":{
+					"type":"version_conflict_engine_exception","reason":"conflict"
+				}}]}`
+		}
+		return 200, `{"task":"node-1:42"}`
+	})
+	ctx := context.Background()
+
+	res, err := UpdateByQuery().
+		Index("books").
+		Query(MatchAll()).
+		SlicesAuto().
+		Refresh(true).
+		Run(ctx, client, &Options{Indices: []string{"ignored"}})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, res.Updated)
+	assert.Equal(t, 1, res.VersionConflicts)
+	assert.Equal(t, "version_conflict_engine_exception", res.Failures[0].Cause.Type)
+
+	res, err = UpdateByQuery().
+		Index("books").
+		Slices(4).
+		WaitForCompletion(false).
+		Run(ctx, client, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "node-1:42", res.Task)
+
+	reqs := requests()
+	assert.Equal(t, "/books/_update_by_query", reqs[0].Path)
+	assert.Equal(t, "refresh=true&slices=auto", reqs[0].Query)
+	assert.Equal(t, `{"query":{"match_all":{}}}`, reqs[0].Body)
+	assert.Equal(t, "slices=4&wait_for_completion=false", reqs[1].Query)
+
+	_, err = UpdateByQuery().Query(MatchAll()).Run(ctx, client, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, len(requests()))
+}